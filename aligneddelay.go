@@ -0,0 +1,74 @@
+package cron
+
+import "time"
+
+// AlignedDelaySchedule represents a recurring duty cycle that is anchored to
+// the Unix epoch, e.g. "Every hour, at a quarter past".  Unlike a
+// ConstantDelaySchedule, its activations do not depend on when it is first
+// consulted.
+type AlignedDelaySchedule struct {
+	Period time.Duration
+	Offset time.Duration
+}
+
+// EveryAligned returns a crontab Schedule that activates once every period,
+// at the Unix epoch plus offset and every multiple of period from there.  For
+// example, EveryAligned(time.Hour, 15*time.Minute) activates at 00:15, 01:15,
+// 02:15 and so on (in UTC).
+// Periods of less than a second are not supported (will round up to 1 second).
+// Any fields less than a Second are truncated, and the offset is reduced
+// modulo the period.
+func EveryAligned(period, offset time.Duration) AlignedDelaySchedule {
+	period = Every(period).Delay
+	offset -= offset % time.Second
+	offset %= period
+	if offset < 0 {
+		offset += period
+	}
+	return AlignedDelaySchedule{
+		Period: period,
+		Offset: offset,
+	}
+}
+
+// Next returns the next time this should be run.
+func (schedule AlignedDelaySchedule) Next(t time.Time) time.Time {
+	n := floorDiv(t.UnixNano()-int64(schedule.Offset), int64(schedule.Period)) + 1
+	return schedule.activation(n).In(t.Location())
+}
+
+// Prev returns the previous time this would have been run.
+func (schedule AlignedDelaySchedule) Prev(t time.Time) time.Time {
+	n := -floorDiv(int64(schedule.Offset)-t.UnixNano(), int64(schedule.Period)) - 1
+	return schedule.activation(n).In(t.Location())
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule AlignedDelaySchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
+
+// Matches returns true if the schedule activates at the given time.
+func (schedule AlignedDelaySchedule) Matches(t time.Time) bool {
+	return (t.UnixNano()-int64(schedule.Offset))%int64(schedule.Period) == 0
+}
+
+// activation returns the nth activation after the Unix epoch.
+func (schedule AlignedDelaySchedule) activation(n int64) time.Time {
+	return time.Unix(0, n*int64(schedule.Period)+int64(schedule.Offset))
+}
+
+// floorDiv returns a divided by b, rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// String returns the "@every" descriptor for the schedule, e.g.
+// "@every 1h offset 15m".
+func (schedule AlignedDelaySchedule) String() string {
+	return every + formatDuration(schedule.Period) + " offset " + formatDuration(schedule.Offset)
+}
@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlignedDelayNext(t *testing.T) {
+	tests := []struct {
+		time           string
+		period, offset time.Duration
+		expected       string
+	}{
+		{"2012-07-09T14:45:00Z", time.Hour, 15 * time.Minute, "2012-07-09T15:15:00Z"},
+		{"2012-07-09T15:14:59Z", time.Hour, 15 * time.Minute, "2012-07-09T15:15:00Z"},
+		{"2012-07-09T15:15:00Z", time.Hour, 15 * time.Minute, "2012-07-09T16:15:00Z"},
+		{"2012-07-09T15:14:59.5Z", time.Hour, 15 * time.Minute, "2012-07-09T15:15:00Z"},
+		{"2012-07-09T23:50:00Z", time.Hour, 15 * time.Minute, "2012-07-10T00:15:00Z"},
+		{"2012-07-09T14:46:00Z", 5 * time.Minute, 0, "2012-07-09T14:50:00Z"},
+
+		// The offset is reduced modulo the period, and negative offsets count back.
+		{"2012-07-09T14:45:00Z", time.Hour, 75 * time.Minute, "2012-07-09T15:15:00Z"},
+		{"2012-07-09T14:45:00Z", time.Hour, -15 * time.Minute, "2012-07-09T15:45:00Z"},
+
+		// Times before the epoch.
+		{"1969-12-31T23:10:00Z", time.Hour, 15 * time.Minute, "1969-12-31T23:15:00Z"},
+	}
+
+	for _, c := range tests {
+		from, _ := time.Parse(time.RFC3339Nano, c.time)
+		expected, _ := time.Parse(time.RFC3339Nano, c.expected)
+		actual := EveryAligned(c.period, c.offset).Next(from)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, %v offset %v: (expected) %v != %v (actual)", c.time, c.period, c.offset, expected, actual)
+		}
+	}
+}
+
+func TestAlignedDelayPrev(t *testing.T) {
+	tests := []struct {
+		time     string
+		expected string
+	}{
+		{"2012-07-09T14:45:00Z", "2012-07-09T14:15:00Z"},
+		{"2012-07-09T15:15:00Z", "2012-07-09T14:15:00Z"},
+		{"2012-07-09T15:15:00.5Z", "2012-07-09T15:15:00Z"},
+		{"1969-12-31T23:10:00Z", "1969-12-31T22:15:00Z"},
+	}
+
+	schedule := EveryAligned(time.Hour, 15*time.Minute)
+	for _, c := range tests {
+		from, _ := time.Parse(time.RFC3339Nano, c.time)
+		expected, _ := time.Parse(time.RFC3339Nano, c.expected)
+		if actual := schedule.Prev(from); !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestAlignedDelayLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip(err)
+	}
+	from := time.Date(2012, 7, 9, 14, 45, 0, 0, tokyo)
+	actual := EveryAligned(time.Hour, 15*time.Minute).Next(from)
+	if expected := time.Date(2012, 7, 9, 15, 15, 0, 0, tokyo); actual != expected {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+func TestAlignedDelayMatches(t *testing.T) {
+	schedule := EveryAligned(time.Hour, 15*time.Minute)
+	tests := []struct {
+		time     string
+		expected bool
+	}{
+		{"2012-07-09T15:15:00Z", true},
+		{"2012-07-09T15:15:00.5Z", false},
+		{"2012-07-09T15:00:00Z", false},
+		{"2012-07-09T20:15:00+05:00", true},
+		{"1969-12-31T23:15:00Z", true},
+	}
+	for _, c := range tests {
+		at, _ := time.Parse(time.RFC3339Nano, c.time)
+		if actual := schedule.Matches(at); actual != c.expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, c.expected, actual)
+		}
+	}
+}
+
+func TestAlignedDelayString(t *testing.T) {
+	schedule := EveryAligned(time.Hour, 15*time.Minute)
+	if actual := schedule.String(); actual != "@every 1h offset 15m" {
+		t.Errorf("unexpected string: %s", actual)
+	}
+	if actual, err := Parse(schedule.String()); err != nil || actual != schedule {
+		t.Errorf("%s => %v, %v", schedule, actual, err)
+	}
+}
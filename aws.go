@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// awsRateUnits maps the units of an AWS rate expression to their duration.
+var awsRateUnits = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// ParseAWS returns a schedule for an Amazon EventBridge schedule expression,
+// which is either "cron(fields)" or "rate(value unit)".
+//
+// The cron fields are minutes, hours, day-of-month, month, day-of-week and
+// year, all of which are required.  They are interpreted the way WithQuartz
+// describes, which is how EventBridge interprets them as well: day-of-week is
+// numbered 1-7 starting from SUN, and exactly one of day-of-month and
+// day-of-week must be "?".  Cron expressions activate in UTC.
+//
+// Rate expressions activate every value units, where value is a positive
+// integer and unit is "minute", "hour" or "day", or their plural when value is
+// not 1, e.g. "rate(5 minutes)".  They return a ConstantDelaySchedule.
+func ParseAWS(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if inner, ok := awsInner(expr, "cron"); ok {
+		return parseAWSCron(inner, expr)
+	}
+	if inner, ok := awsInner(expr, "rate"); ok {
+		return parseAWSRate(inner, expr)
+	}
+	return nil, fmt.Errorf("expected cron(...) or rate(...): %q", expr)
+}
+
+// awsInner returns the text between the parentheses of an expression such as
+// "cron(...)", if the expression has the given name.
+func awsInner(expr, name string) (string, bool) {
+	if !strings.HasPrefix(expr, name+"(") || !strings.HasSuffix(expr, ")") {
+		return "", false
+	}
+	return strings.TrimSpace(expr[len(name)+1 : len(expr)-1]), true
+}
+
+// parseAWSCron parses the fields of an AWS cron expression.
+func parseAWSCron(fields, expr string) (Schedule, error) {
+	// The parser's prefixes, which are written "KEY=value", have no place in
+	// an AWS expression, whose fields never contain "=".
+	if f := strings.Fields(fields); len(f) > 0 && strings.Contains(f[0], "=") {
+		key, _, _ := strings.Cut(f[0], "=")
+		if key == "TZ" || key == "CRON_TZ" {
+			return nil, fmt.Errorf("time zones are not supported in AWS cron expressions: %q", expr)
+		}
+		return nil, fmt.Errorf("prefixes such as %s= are not supported in AWS cron expressions: %q", key, expr)
+	}
+	if strings.HasPrefix(fields, "@") {
+		return nil, fmt.Errorf("descriptors are not supported in AWS cron expressions: %q", expr)
+	}
+	return NewParser(WithQuartz(), WithoutSeconds(), WithYear(), WithLocation(time.UTC)).Parse(fields)
+}
+
+// parseAWSRate parses the value and unit of an AWS rate expression.
+func parseAWSRate(rate, expr string) (Schedule, error) {
+	parts := strings.Fields(rate)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected rate(value unit): %q", expr)
+	}
+	value, err := strconv.Atoi(parts[0])
+	if err != nil || value < 1 {
+		return nil, fmt.Errorf("rate value must be a positive integer: %q", expr)
+	}
+	unit := parts[1]
+	if value != 1 {
+		if !strings.HasSuffix(unit, "s") {
+			return nil, fmt.Errorf("rate unit must be plural for values other than 1: %q", expr)
+		}
+		unit = strings.TrimSuffix(unit, "s")
+	}
+	d, ok := awsRateUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("rate unit must be minute, hour or day: %q", expr)
+	}
+	return Every(time.Duration(value) * d), nil
+}
@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAWSCron(t *testing.T) {
+	tests := []struct {
+		expr, spec string
+	}{
+		{"cron(0 12 * * ? *)", "TZ=UTC 0 0 12 * * ? *"},
+		{"cron(15 10 ? * 6L 2022-2023)", "TZ=UTC 0 15 10 ? * FRIL 2022-2023"},
+		{"cron(0/5 8-17 ? * MON-FRI *)", "TZ=UTC 0 */5 8-17 ? * MON-FRI *"},
+		{"cron(0 9 ? * 2#1 *)", "TZ=UTC 0 0 9 ? * MON#1 *"},
+		{"cron(0 8 1 * ? *)", "TZ=UTC 0 0 8 1 * ? *"},
+		{"cron(0 0 ? * 1 *)", "TZ=UTC 0 0 0 ? * SUN *"},
+		{"cron(0 0 ? * 7 *)", "TZ=UTC 0 0 0 ? * SAT *"},
+		{" cron( 0 18 L * ? * ) ", "TZ=UTC 0 0 18 L * ? *"},
+	}
+	for _, c := range tests {
+		actual, err := ParseAWS(c.expr)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.expr, err)
+			continue
+		}
+		expected, err := Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Equal(actual, expected) {
+			t.Errorf("%s => %v, expected %s", c.expr, actual, c.spec)
+		}
+	}
+}
+
+func TestParseAWSRate(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected time.Duration
+	}{
+		{"rate(1 minute)", time.Minute},
+		{"rate(5 minutes)", 5 * time.Minute},
+		{"rate(1 hour)", time.Hour},
+		{"rate(12 hours)", 12 * time.Hour},
+		{"rate(1 day)", 24 * time.Hour},
+		{"rate(7 days)", 7 * 24 * time.Hour},
+	}
+	for _, c := range tests {
+		actual, err := ParseAWS(c.expr)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.expr, err)
+			continue
+		}
+		if actual != Every(c.expected) {
+			t.Errorf("%s => %#v, expected %v", c.expr, actual, c.expected)
+		}
+	}
+}
+
+func TestParseAWSErrors(t *testing.T) {
+	tests := []struct {
+		expr, err string
+	}{
+		{"0 12 * * ? *", "expected cron(...) or rate(...)"},
+		{"cron(0 12 * * * *)", "?"},
+		{"cron(0 12 1 * MON *)", "?"},
+		{"cron(0 12 * * ?)", "expected 6 fields, found 5"},
+		{"cron(0 0 12 * * ? *)", "does not accept a seconds field"},
+		{"cron(0 12 ? * 0 *)", "below minimum (1)"},
+		{"cron(TZ=UTC 0 12 * * ? *)", "time zones are not supported"},
+		{"cron(CRON_TZ=UTC\t0 12 * * ? *)", "time zones are not supported"},
+		{"cron(GAP=SKIP 0 12 * * ? *)", "prefixes such as GAP= are not supported"},
+		{"cron(DOMDOW=AND 0 12 * * ? *)", "prefixes such as DOMDOW= are not supported"},
+		{"cron(REPEAT=BOTH 0 12 * * ? *)", "prefixes such as REPEAT= are not supported"},
+		{"cron(@daily)", "descriptors are not supported"},
+		{"rate(5)", "expected rate(value unit)"},
+		{"rate(0 minutes)", "positive integer"},
+		{"rate(-1 minute)", "positive integer"},
+		{"rate(5 minute)", "must be plural"},
+		{"rate(1 minutes)", "must be minute, hour or day"},
+		{"rate(30 seconds)", "must be minute, hour or day"},
+	}
+	for _, c := range tests {
+		_, err := ParseAWS(c.expr)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%q => %v, expected error containing %q", c.expr, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// A Builder constructs a SpecSchedule field by field, as an alternative to
+// assembling a spec out of strings.  Fields that are not set match every
+// value, as "*" does.  For example,
+//
+//	NewBuilder().AtSeconds(0).AtMinutes(0, 30).AtHours(9, 17).
+//		OnWeekdays(time.Monday, time.Friday).Build()
+//
+// builds the same schedule as "0 0,30 9,17 * * MON,FRI".
+//
+// Values are checked against the same bounds that Parse checks them against,
+// and any that are outside of them are reported when the schedule is built.
+type Builder struct {
+	bits  [6]uint64 // bits of each field but the year, or 0 if it is not set
+	years yearSet
+	loc   *time.Location
+	errs  []error
+}
+
+// NewBuilder returns a Builder whose fields all match every value.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// AtSeconds restricts the schedule to the given seconds.
+func (b *Builder) AtSeconds(seconds ...int) *Builder {
+	return b.values(SecondField, seconds...)
+}
+
+// AtMinutes restricts the schedule to the given minutes.
+func (b *Builder) AtMinutes(minutes ...int) *Builder {
+	return b.values(MinuteField, minutes...)
+}
+
+// AtHours restricts the schedule to the given hours.
+func (b *Builder) AtHours(hours ...int) *Builder {
+	return b.values(HourField, hours...)
+}
+
+// OnDays restricts the schedule to the given days of the month.
+func (b *Builder) OnDays(days ...int) *Builder {
+	return b.values(DomField, days...)
+}
+
+// InMonths restricts the schedule to the given months.
+func (b *Builder) InMonths(months ...time.Month) *Builder {
+	for _, m := range months {
+		b.values(MonthField, int(m))
+	}
+	return b
+}
+
+// OnWeekdays restricts the schedule to the given days of the week.
+func (b *Builder) OnWeekdays(days ...time.Weekday) *Builder {
+	for _, d := range days {
+		b.values(DowField, int(d))
+	}
+	return b
+}
+
+// InYears restricts the schedule to the given years.
+func (b *Builder) InYears(years ...int) *Builder {
+	for _, y := range years {
+		if b.inBounds(YearField, y, strconv.Itoa(y)) {
+			b.years.add(uint(y))
+		}
+	}
+	return b
+}
+
+// SecondRange restricts the schedule to every step seconds from start through
+// end.
+func (b *Builder) SecondRange(start, end, step int) *Builder {
+	return b.rng(SecondField, start, end, step)
+}
+
+// MinuteRange restricts the schedule to every step minutes from start through
+// end.
+func (b *Builder) MinuteRange(start, end, step int) *Builder {
+	return b.rng(MinuteField, start, end, step)
+}
+
+// HourRange restricts the schedule to every step hours from start through end.
+func (b *Builder) HourRange(start, end, step int) *Builder {
+	return b.rng(HourField, start, end, step)
+}
+
+// DayRange restricts the schedule to every step days of the month from start
+// through end.
+func (b *Builder) DayRange(start, end, step int) *Builder {
+	return b.rng(DomField, start, end, step)
+}
+
+// MonthRange restricts the schedule to every step months from start through
+// end.
+func (b *Builder) MonthRange(start, end time.Month, step int) *Builder {
+	return b.rng(MonthField, int(start), int(end), step)
+}
+
+// WeekdayRange restricts the schedule to the days of the week from start
+// through end.  As in a spec, a range beginning after its end wraps around, so
+// WeekdayRange(time.Saturday, time.Sunday) stands for the weekend.
+func (b *Builder) WeekdayRange(start, end time.Weekday) *Builder {
+	return b.rng(DowField, int(start), int(end), 1)
+}
+
+// EveryNSeconds restricts the schedule to every n seconds from the start of
+// each minute, as "*/n" does.
+func (b *Builder) EveryNSeconds(n int) *Builder {
+	return b.step(SecondField, n)
+}
+
+// EveryNMinutes restricts the schedule to every n minutes from the start of
+// each hour, as "*/n" does.
+func (b *Builder) EveryNMinutes(n int) *Builder {
+	return b.step(MinuteField, n)
+}
+
+// EveryNHours restricts the schedule to every n hours from midnight, as "*/n"
+// does.
+func (b *Builder) EveryNHours(n int) *Builder {
+	return b.step(HourField, n)
+}
+
+// EveryNDays restricts the schedule to every n days of the month from the 1st,
+// as "*/n" does.
+func (b *Builder) EveryNDays(n int) *Builder {
+	return b.step(DomField, n)
+}
+
+// EveryNMonths restricts the schedule to every n months from January, as "*/n"
+// does.
+func (b *Builder) EveryNMonths(n int) *Builder {
+	return b.step(MonthField, n)
+}
+
+// In sets the location the schedule is interpreted in.  It defaults to
+// time.Local.
+func (b *Builder) In(loc *time.Location) *Builder {
+	b.loc = loc
+	return b
+}
+
+// Build returns the schedule, or an error naming each value that is outside
+// of the bounds of its field and each step that is not positive.
+func (b *Builder) Build() (*SpecSchedule, error) {
+	if err := errors.Join(b.errs...); err != nil {
+		return nil, err
+	}
+	loc := b.loc
+	if loc == nil {
+		loc = time.Local
+	}
+	return &SpecSchedule{
+		Second:   b.field(SecondField),
+		Minute:   b.field(MinuteField),
+		Hour:     b.field(HourField),
+		Dom:      b.field(DomField),
+		Month:    b.field(MonthField),
+		Dow:      b.field(DowField),
+		Year:     b.years,
+		Location: loc,
+	}, nil
+}
+
+// field returns the bits of a field, which match every value if it is not set.
+func (b *Builder) field(f Field) uint64 {
+	if b.bits[f] == 0 {
+		return all(fieldBounds[f])
+	}
+	return b.bits[f]
+}
+
+// values adds the given values to a field.
+func (b *Builder) values(f Field, values ...int) *Builder {
+	for _, v := range values {
+		if b.inBounds(f, v, strconv.Itoa(v)) {
+			b.bits[f] |= 1 << uint(v)
+		}
+	}
+	return b
+}
+
+// rng adds a stepped range to a field.  As in a spec, a range beginning after
+// its end wraps around.
+func (b *Builder) rng(f Field, start, end, step int) *Builder {
+	okStart := b.inBounds(f, start, fmt.Sprintf("beginning of range (%d)", start))
+	okEnd := b.inBounds(f, end, fmt.Sprintf("end of range (%d)", end))
+	if !okStart || !okEnd || !b.positive(f, step) {
+		return b
+	}
+	if start > end {
+		b.bits[f] |= getWrappedBits(uint(start), uint(end), uint(step), fieldBounds[f])
+	} else {
+		b.bits[f] |= getBits(uint(start), uint(end), uint(step))
+	}
+	return b
+}
+
+// step adds a stepped star to a field.
+func (b *Builder) step(f Field, n int) *Builder {
+	if b.positive(f, n) {
+		r := fieldBounds[f]
+		b.bits[f] |= getBits(r.min, r.max, uint(n)) | starBit
+	}
+	return b
+}
+
+// inBounds returns true if v is within the bounds of the field, and otherwise
+// records an error describing v as what, e.g. "end of range (24)".
+func (b *Builder) inBounds(f Field, v int, what string) bool {
+	r := fieldBounds[f]
+	switch {
+	case v < int(r.min):
+		b.errs = append(b.errs, fmt.Errorf("%s: %s below minimum (%d)", f, what, r.min))
+	case v > int(r.max):
+		b.errs = append(b.errs, fmt.Errorf("%s: %s above maximum (%d)", f, what, r.max))
+	default:
+		return true
+	}
+	return false
+}
+
+// positive returns true if the step is positive, and otherwise records an
+// error.
+func (b *Builder) positive(f Field, step int) bool {
+	if step <= 0 {
+		b.errs = append(b.errs, fmt.Errorf("%s: %w: %d", f, ErrZeroStep, step))
+		return false
+	}
+	return true
+}
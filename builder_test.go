@@ -0,0 +1,97 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	entries := []struct {
+		builder  *Builder
+		expected string
+	}{
+		{NewBuilder(), "* * * * * *"},
+		{NewBuilder().AtSeconds(0).AtMinutes(0, 30).AtHours(9, 17).OnWeekdays(time.Monday, time.Friday),
+			"0 0,30 9,17 * * 1,5"},
+		{NewBuilder().AtSeconds(0).EveryNMinutes(15), "0 */15 * * * *"},
+		{NewBuilder().AtSeconds(30).AtMinutes(0).HourRange(9, 17, 2).WeekdayRange(time.Monday, time.Friday),
+			"30 0 9-17/2 * * 1-5"},
+		{NewBuilder().AtSeconds(0).AtMinutes(0).AtHours(0).OnDays(1, 15).InMonths(time.January, time.July),
+			"0 0 0 1,15 1,7 *"},
+		{NewBuilder().AtSeconds(0).AtMinutes(0).AtHours(0).WeekdayRange(time.Saturday, time.Sunday),
+			"0 0 0 * * 0,6"},
+		{NewBuilder().AtSeconds(0).AtMinutes(0).AtHours(12).EveryNDays(10).EveryNMonths(3),
+			"0 0 12 */10 */3 *"},
+		{NewBuilder().AtSeconds(0).AtMinutes(0).AtHours(0).OnDays(1).InMonths(time.January).InYears(2030, 2031),
+			"0 0 0 1 1 * 2030,2031"},
+		{NewBuilder().AtSeconds(0).MinuteRange(50, 10, 10).AtHours(3).In(tokyo),
+			"TZ=Asia/Tokyo 0 0,10,50 3 * * *"},
+		{NewBuilder().EveryNSeconds(20).AtMinutes(5).In(time.UTC), "TZ=UTC */20 5 * * * *"},
+		{NewBuilder().AtSeconds(0).AtMinutes(0).AtHours(0).DayRange(1, 7, 1).MonthRange(time.March, time.May, 1),
+			"0 0 0 1-7 3-5 *"},
+	}
+	for _, c := range entries {
+		actual, err := c.builder.Build()
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.expected, err)
+			continue
+		}
+		if actual.String() != c.expected {
+			t.Errorf("%s => got %s", c.expected, actual)
+		}
+		reparsed, err := Parse(actual.String())
+		if err != nil {
+			t.Errorf("%s => unexpected error reparsing: %v", c.expected, err)
+			continue
+		}
+		if !reflect.DeepEqual(reparsed, actual) {
+			t.Errorf("%s => (built) %v != %v (reparsed)", c.expected, actual, reparsed)
+		}
+	}
+}
+
+func TestBuilderMatchesParse(t *testing.T) {
+	built, err := NewBuilder().AtSeconds(0).AtMinutes(0, 30).AtHours(9, 17).
+		OnWeekdays(time.Monday, time.Friday).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := MustParse("0 0,30 9,17 * * MON,FRI")
+	if !reflect.DeepEqual(built, parsed) {
+		t.Errorf("(built) %v != %v (parsed)", built, parsed)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	entries := []struct {
+		builder  *Builder
+		expected string
+	}{
+		{NewBuilder().AtHours(24), `hours: 24 above maximum (23)`},
+		{NewBuilder().OnDays(0), `day-of-month: 0 below minimum (1)`},
+		{NewBuilder().InMonths(time.Month(13)), `month: 13 above maximum (12)`},
+		{NewBuilder().OnWeekdays(time.Weekday(7)), `day-of-week: 7 above maximum (6)`},
+		{NewBuilder().InYears(1969), `year: 1969 below minimum (1970)`},
+		{NewBuilder().AtMinutes(-5), `minutes: -5 below minimum (0)`},
+		{NewBuilder().HourRange(1, 24, 1), `hours: end of range (24) above maximum (23)`},
+		{NewBuilder().DayRange(0, 40, 1), "day-of-month: beginning of range (0) below minimum (1)\n" +
+			"day-of-month: end of range (40) above maximum (31)"},
+		{NewBuilder().HourRange(1, -2, 1).AtSeconds(60), "hours: end of range (-2) below minimum (0)\n" +
+			"seconds: 60 above maximum (59)"},
+		{NewBuilder().SecondRange(0, 30, -1), `seconds: step must be a positive integer: -1`},
+	}
+	for _, c := range entries {
+		_, err := c.builder.Build()
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("expected %q, got %v", c.expected, err)
+		}
+	}
+
+	_, err := NewBuilder().EveryNMinutes(0).Build()
+	if !errors.Is(err, ErrZeroStep) || err.Error() != "minutes: step must be a positive integer: 0" {
+		t.Errorf("EveryNMinutes(0) => expected ErrZeroStep for the minutes, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package cron
+
+import "time"
+
+// Calendar reports the days on which scheduled activations must not happen,
+// such as public holidays.
+type Calendar interface {
+	// IsExcluded returns true if activating at t is not allowed.
+	IsExcluded(t time.Time) bool
+}
+
+// DateCalendar is a Calendar that excludes a set of dates.  Dates are compared
+// by year, month and day only, in the location of the time being checked.
+type DateCalendar struct {
+	dates map[date]bool
+}
+
+// date is a day in no particular location.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewDateCalendar returns a DateCalendar excluding the days of the given
+// times, each taken in its own location.
+func NewDateCalendar(dates ...time.Time) *DateCalendar {
+	c := &DateCalendar{dates: make(map[date]bool)}
+	for _, t := range dates {
+		c.Add(t)
+	}
+	return c
+}
+
+// Add excludes the day of the given time, taken in its own location.
+func (c *DateCalendar) Add(t time.Time) {
+	year, month, day := t.Date()
+	c.dates[date{year, month, day}] = true
+}
+
+// IsExcluded returns true if t falls on one of the calendar's dates.
+func (c *DateCalendar) IsExcluded(t time.Time) bool {
+	year, month, day := t.Date()
+	return c.dates[date{year, month, day}]
+}
+
+// CalendarSchedule keeps a schedule from activating on the days excluded by a
+// calendar.  Activations on those days are either skipped or, if Defer is set,
+// moved to the same time on the next day that is not excluded.
+type CalendarSchedule struct {
+	Schedule Schedule
+	Calendar Calendar
+	Defer    bool
+}
+
+// SkipCalendar returns a Schedule that activates like s, except on the days
+// excluded by c.  Days are those of the schedule's location if it is a
+// *SpecSchedule, and of the given time's location otherwise.
+func SkipCalendar(s Schedule, c Calendar) Schedule {
+	return CalendarSchedule{Schedule: s, Calendar: c}
+}
+
+// DeferCalendar returns a Schedule that activates like s, except that
+// activations on the days excluded by c are moved to the same time on the
+// next day that is not excluded.
+func DeferCalendar(s Schedule, c Calendar) Schedule {
+	return CalendarSchedule{Schedule: s, Calendar: c, Defer: true}
+}
+
+// Next returns the next activation of the wrapped schedule that is not on an
+// excluded day, or the zero time if there is none within a bounded search.  If
+// activations are deferred, it returns the earlier of the next deferred
+// activation and the next activation that needs no deferring.
+func (schedule CalendarSchedule) Next(t time.Time) time.Time {
+	var deferred time.Time
+	for i := 0; i < exclusionSearchLimit; i++ {
+		t = schedule.Schedule.Next(t)
+		if !deferred.IsZero() && (t.IsZero() || t.After(deferred)) {
+			return deferred
+		}
+		if t.IsZero() || !schedule.excluded(t) {
+			return t
+		}
+		if schedule.Defer && deferred.IsZero() {
+			deferred = schedule.deferred(t)
+		}
+	}
+	return deferred
+}
+
+// Prev returns the previous activation of the wrapped schedule that was not on
+// an excluded day, or the zero time if there is none within a bounded search.
+// If activations are deferred, an excluded activation whose deferred time has
+// already passed is returned at that time.
+func (schedule CalendarSchedule) Prev(t time.Time) time.Time {
+	prev := t
+	for i := 0; i < exclusionSearchLimit; i++ {
+		prev = previous(schedule.Schedule, prev)
+		if prev.IsZero() || !schedule.excluded(prev) {
+			return prev
+		}
+		if schedule.Defer {
+			if deferred := schedule.deferred(prev); !deferred.IsZero() && deferred.Before(t) {
+				return deferred
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule CalendarSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
+
+// excluded returns true if the calendar excludes the day of t.
+func (schedule CalendarSchedule) excluded(t time.Time) bool {
+	if s, ok := schedule.Schedule.(*SpecSchedule); ok {
+		t = t.In(s.Location)
+	}
+	return schedule.Calendar.IsExcluded(t)
+}
+
+// deferred returns the same time as t on the next day that is not excluded,
+// or the zero time if there is none within a bounded search.
+func (schedule CalendarSchedule) deferred(t time.Time) time.Time {
+	origLocation := t.Location()
+	if s, ok := schedule.Schedule.(*SpecSchedule); ok {
+		t = t.In(s.Location)
+	}
+	for i := 0; i < exclusionSearchLimit; i++ {
+		t = t.AddDate(0, 0, 1)
+		if !schedule.Calendar.IsExcluded(t) {
+			return t.In(origLocation)
+		}
+	}
+	return time.Time{}
+}
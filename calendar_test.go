@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateCalendar(t *testing.T) {
+	c := NewDateCalendar(getTime("Wed Jul 4 00:00 2012"))
+	c.Add(time.Date(2012, time.December, 25, 23, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{getTime("Wed Jul 4 00:00 2012"), true},
+		{getTime("Wed Jul 4 23:59:59 2012"), true},
+		{getTime("Thu Jul 5 00:00 2012"), false},
+		{getTime("Thu Jul 4 00:00 2013"), false},
+		{time.Date(2012, time.December, 25, 1, 0, 0, 0, time.FixedZone("East", 14*3600)), true},
+	}
+	for _, test := range tests {
+		if actual := c.IsExcluded(test.time); actual != test.expected {
+			t.Errorf("%v: (expected) %v != %v (actual)", test.time, test.expected, actual)
+		}
+	}
+}
+
+func TestCalendarNext(t *testing.T) {
+	holidays := NewDateCalendar(
+		getTime("Tue Jul 31 00:00 2012"),
+		getTime("Wed Aug 1 00:00 2012"),
+		getTime("Thu Aug 2 00:00 2012"),
+		getTime("Sat Sep 1 00:00 2012"),
+	)
+	lastDay := MustParse("0 0 9 L * *")
+	firstDay := MustParse("0 0 9 1 * *")
+	daily := MustParse("0 0 9 * * *")
+
+	tests := []struct {
+		schedule Schedule
+		time     string
+		expected string
+	}{
+		// A holiday on the only day of the month that the spec activates.
+		{SkipCalendar(lastDay, holidays), "Mon Jul 9 00:00 2012", "Fri Aug 31 09:00 2012"},
+		{SkipCalendar(firstDay, holidays), "Mon Jul 9 00:00 2012", "Mon Oct 1 09:00 2012"},
+		{DeferCalendar(lastDay, holidays), "Mon Jul 9 00:00 2012", "Fri Aug 3 09:00 2012"},
+		{DeferCalendar(firstDay, holidays), "Fri Aug 3 09:00 2012", "Sun Sep 2 09:00 2012"},
+
+		// Daily activations.
+		{SkipCalendar(daily, holidays), "Mon Jul 30 09:00 2012", "Fri Aug 3 09:00 2012"},
+		{DeferCalendar(daily, holidays), "Mon Jul 30 09:00 2012", "Fri Aug 3 09:00 2012"},
+
+		// Activations that do not need deferring come first.
+		{DeferCalendar(MustParse("0 0 * * * *"), holidays), "Thu Aug 2 08:30 2012", "Fri Aug 3 00:00 2012"},
+
+		// Schedules that stop activating.
+		{SkipCalendar(MustParse("0 0 9 31 7 * 2012"), holidays), "Mon Jul 9 00:00 2012", ""},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.Next(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestCalendarPrev(t *testing.T) {
+	holidays := NewDateCalendar(getTime("Wed Aug 1 00:00 2012"))
+	firstDay := MustParse("0 0 9 1 * *")
+
+	tests := []struct {
+		schedule Schedule
+		time     string
+		expected string
+	}{
+		{SkipCalendar(firstDay, holidays), "Fri Aug 3 00:00 2012", "Sun Jul 1 09:00 2012"},
+		{DeferCalendar(firstDay, holidays), "Fri Aug 3 00:00 2012", "Thu Aug 2 09:00 2012"},
+		{DeferCalendar(firstDay, holidays), "Thu Aug 2 09:00 2012", "Sun Jul 1 09:00 2012"},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.(PrevSchedule).Prev(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestCalendarLocation(t *testing.T) {
+	// 08:00 in Tokyo on the 1st is still the day before in UTC, but the days
+	// are taken in the schedule's location.
+	holidays := NewDateCalendar(time.Date(2012, time.August, 1, 0, 0, 0, 0, time.UTC))
+	schedule := SkipCalendar(MustParse("TZ=Asia/Tokyo 0 0 8 1 * *"), holidays)
+	from := time.Date(2012, time.July, 9, 0, 0, 0, 0, time.UTC)
+	expected := time.Date(2012, time.August, 31, 23, 0, 0, 0, time.UTC)
+	if actual := schedule.Next(from); !actual.Equal(expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
@@ -0,0 +1,119 @@
+package cron
+
+import "time"
+
+// Conflicts returns the times at which both schedules activate that are after
+// from and not after from plus window, in order.  It is meaningful for
+// schedules whose activations do not depend on when they are consulted, such
+// as SpecSchedules and the schedules of EveryAligned.
+func Conflicts(a, b Schedule, window time.Duration, from time.Time) []time.Time {
+	var times []time.Time
+	end := from.Add(window)
+	ta, tb := nextAfter(a, from), nextAfter(b, from)
+	for !ta.IsZero() && !tb.IsZero() && !ta.After(end) && !tb.After(end) {
+		switch {
+		case ta.Equal(tb):
+			times = append(times, ta)
+			ta, tb = nextAfter(a, ta), nextAfter(b, tb)
+		case ta.Before(tb):
+			// Skip a ahead to the first activation at or after tb.
+			ta = nextAfter(a, tb.Add(-time.Nanosecond))
+		default:
+			tb = nextAfter(b, ta.Add(-time.Nanosecond))
+		}
+	}
+	return times
+}
+
+// nextAfter returns the next activation of the schedule after t, or the zero
+// time if the schedule stops activating, as NextN judges it.
+func nextAfter(s Schedule, t time.Time) time.Time {
+	next := s.Next(t)
+	if !next.After(t) {
+		return time.Time{}
+	}
+	return next
+}
+
+// EverCoincide returns true if the two schedules ever activate at the same
+// time.  It reasons over the fields of the schedules rather than searching
+// through their activations, and considers wall clock times only: activations
+// moved by daylight savings transitions (see GapPolicy) are not taken into
+// account.
+//
+// Schedules in different locations are conservatively assumed to coincide if
+// both ever activate, as whether they do depends on the offsets of the
+// locations over time.
+func EverCoincide(a, b *SpecSchedule) bool {
+	if a.Location.String() != b.Location.String() {
+		return Satisfiable(a) && Satisfiable(b)
+	}
+	if a.Second&b.Second&^starBit == 0 || a.Minute&b.Minute&^starBit == 0 ||
+		a.Hour&b.Hour&^starBit == 0 || a.Month&b.Month&^starBit == 0 {
+		return false
+	}
+	if a.plainDays() && b.plainDays() {
+		return plainDaysCoincide(a, b)
+	}
+
+	// Otherwise the days that match depend on the calendar, which repeats
+	// every 400 years.
+	from, to := 2000, 2399
+	if !a.Year.isZero() || !b.Year.isZero() {
+		from, to = int(years.min), int(years.max)
+	}
+	for year := from; year <= to; year++ {
+		if !a.Year.contains(year) || !b.Year.contains(year) {
+			continue
+		}
+		for month := time.January; month <= time.December; month++ {
+			if 1<<uint(month)&a.Month&b.Month > 0 && a.dayMask(year, month)&b.dayMask(year, month) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// plainDays returns true if the schedule's days are given by plain
+// day-of-month and day-of-week values alone, in any year.
+func (s *SpecSchedule) plainDays() bool {
+	return !s.DomLast && s.DomWeekday == 0 && !s.DomLastWeekday &&
+		s.DowNth == 0 && s.DowLast == 0 && s.Year.isZero()
+}
+
+// plainDaysCoincide returns true if two schedules with plainDays share a day in
+// one of the months they share.  As every date falls on every day of the week
+// in some year, it suffices to find a day of the month on which both match a
+// common day of the week.
+func plainDaysCoincide(a, b *SpecSchedule) bool {
+	last := 0
+	for month := time.January; month <= time.December; month++ {
+		if 1<<uint(month)&a.Month&b.Month > 0 {
+			last = max(last, daysInMonth(2000, month))
+		}
+	}
+	for day := 1; day <= last; day++ {
+		if a.weekdaysOn(day)&b.weekdaysOn(day) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdaysOn returns the days of the week, as bits, on which a schedule with
+// plainDays matches the given day of the month.
+func (s *SpecSchedule) weekdaysOn(day int) uint64 {
+	week := getBits(dow.min, dow.max, 1)
+	domMatches := 1<<uint(day)&s.Dom > 0
+	switch {
+	case s.daysAnd() && domMatches:
+		return s.Dow & week
+	case s.daysAnd():
+		return 0
+	case domMatches:
+		return week
+	default:
+		return s.Dow & week
+	}
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConflicts(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return from.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	entries := []struct {
+		a, b     Schedule
+		window   time.Duration
+		expected []time.Time
+	}{
+		{MustParse("TZ=UTC 0 */15 * * * *"), MustParse("TZ=UTC 0 0,30 * * * *"), 2 * time.Hour,
+			[]time.Time{at(0, 30), at(1, 0), at(1, 30), at(2, 0)}},
+		{MustParse("TZ=UTC 0 5 * * * *"), MustParse("TZ=UTC 0 10 * * * *"), 24 * time.Hour, nil},
+		{MustParse("TZ=UTC 0 0 */6 * * *"), EveryAligned(4*time.Hour, 0), 24 * time.Hour,
+			[]time.Time{at(12, 0), at(24, 0)}},
+		{Union(MustParse("TZ=UTC 0 0 1 * * *"), MustParse("TZ=UTC 0 0 3 * * *")), MustParse("TZ=UTC 0 0 3 * * *"),
+			48 * time.Hour, []time.Time{at(3, 0), at(27, 0)}},
+	}
+	for i, c := range entries {
+		actual := Conflicts(c.a, c.b, c.window, from)
+		if len(actual) == 0 && len(c.expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%d => (expected) %v != %v (actual)", i, c.expected, actual)
+		}
+	}
+}
+
+func TestConflictsAcrossLocations(t *testing.T) {
+	// London is on UTC until the clocks go forward on March 31st.
+	from := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)
+	actual := Conflicts(MustParse("TZ=UTC 0 0 9 * * *"), MustParse("TZ=Europe/London 0 0 9 * * *"), 7*24*time.Hour, from)
+	if len(actual) != 3 || !actual[2].Equal(time.Date(2024, 3, 30, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v", actual)
+	}
+}
+
+func TestEverCoincide(t *testing.T) {
+	spec := func(s string) *SpecSchedule { return MustParse("TZ=UTC " + s).(*SpecSchedule) }
+	andDays := func(s string) *SpecSchedule {
+		sched, err := NewParser(WithDomDowPolicy(DomAndDow)).Parse("TZ=UTC " + s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sched.(*SpecSchedule)
+	}
+	entries := []struct {
+		a, b     *SpecSchedule
+		expected bool
+	}{
+		{spec("* * * * * *"), spec("30 * * * * *"), true},
+		{spec("0 0 9 * * MON"), spec("0 0 9 1 * *"), true},
+		{spec("0 0 9 * * MON"), spec("0 0 9 * * TUE"), false},
+		{spec("0 0 9 * * MON"), spec("0 0 10 * * MON"), false},
+		{spec("0 0 9 * JAN *"), spec("0 0 9 * FEB *"), false},
+		{spec("0 0 9 31 * *"), spec("0 0 9 * FEB *"), false},
+		{spec("0 0 9 30 * *"), spec("0 0 9 * FEB,APR *"), true},
+		{spec("0 0 9 29 FEB *"), spec("0 0 9 * * SUN"), true},
+		{spec("0 0 9 13 * FRI"), spec("0 0 9 * * MON"), true},
+		{spec("0 0 9 13 * FRI"), spec("0 0 9 14 * SAT"), true},
+		{spec("0 0 9 1-7 * *"), spec("0 0 9 8-14 * *"), false},
+		{spec("0 0 9 */2 * MON"), spec("0 0 9 2 * *"), false},
+		{andDays("0 0 9 13 * FRI"), andDays("0 0 9 13 * MON"), false},
+		{andDays("0 0 9 13 * FRI"), spec("0 0 9 * * FRI"), true},
+		{spec("0 0 9 L * *"), spec("0 0 9 15 * *"), false},
+		{spec("0 0 9 L * *"), spec("0 0 9 28 FEB *"), true},
+		{spec("0 0 9 L * *"), spec("0 0 9 30 JAN *"), false},
+		{spec("0 0 9 * * 1#1"), spec("0 0 9 8-31 * *"), false},
+		{spec("0 0 9 * * 1#1"), spec("0 0 9 7 * *"), true},
+		{spec("0 0 9 LW * *"), spec("0 0 9 * * SAT,SUN"), false},
+		{spec("0 0 9 * * * 2030"), spec("0 0 9 * * * 2031"), false},
+		{spec("0 0 12 1 1 * 2030"), spec("0 0 12 * * WED"), false},
+		{spec("0 0 12 1 1 * 2030"), spec("0 0 12 * * TUE"), true},
+		{spec("0 0 12 1 1 * 2030"), spec("0 0 12 1 1 * 2030-2040"), true},
+		{spec("0 0 * * * *"), MustParse("TZ=Asia/Tokyo 0 0 * * * *").(*SpecSchedule), true},
+		{spec("0 0 0 30 2 *"), MustParse("TZ=Asia/Tokyo 0 0 * * * *").(*SpecSchedule), false},
+	}
+	for i, c := range entries {
+		if actual := EverCoincide(c.a, c.b); actual != c.expected {
+			t.Errorf("%d: %v, %v => expected %v", i, c.a, c.b, c.expected)
+		}
+		if actual := EverCoincide(c.b, c.a); actual != c.expected {
+			t.Errorf("%d: %v, %v => expected %v", i, c.b, c.a, c.expected)
+		}
+	}
+}
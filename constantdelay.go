@@ -1,9 +1,15 @@
 package cron
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // ConstantDelaySchedule represents a simple recurring duty cycle, e.g. "Every 5 minutes".
-// It does not support jobs more frequent than once a second.
+// Schedules built by Every activate on the second; those built by EveryExact
+// may activate more often than once a second.
+// It has no Matches method, as its activations depend on when it is first
+// consulted; see AlignedDelaySchedule for a duty cycle anchored to the epoch.
 type ConstantDelaySchedule struct {
 	Delay time.Duration
 }
@@ -20,14 +26,60 @@ func Every(duration time.Duration) ConstantDelaySchedule {
 	}
 }
 
+// EveryExact returns a crontab Schedule that activates once every duration,
+// keeping its full precision.  Unlike Every, it supports delays of less than a
+// second.  Delays that are not positive round up to 1 nanosecond.
+func EveryExact(duration time.Duration) ConstantDelaySchedule {
+	if duration < time.Nanosecond {
+		duration = time.Nanosecond
+	}
+	return ConstantDelaySchedule{
+		Delay: duration,
+	}
+}
+
 // Next returns the next time this should be run.
-// This rounds so that the next activation time will be on the second.
+// If the delay is a whole number of seconds, this rounds so that the next
+// activation time will be on the second.  Otherwise it is exactly one delay
+// after the given time.
 func (schedule ConstantDelaySchedule) Next(t time.Time) time.Time {
-	return t.Add(schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
+	return t.Add(schedule.Delay - schedule.rounding(t))
 }
 
-// Next returns the next time this should be run.
-// This rounds so that the next activation time will be on the second.
+// Prev returns the previous time this would have been run.
+// It rounds the same way as Next.
+func (schedule ConstantDelaySchedule) Prev(t time.Time) time.Time {
+	return t.Add(-schedule.Delay - schedule.rounding(t))
+}
+
+// rounding returns how far t is from the second, or zero if the delay has
+// sub-second resolution.
+func (schedule ConstantDelaySchedule) rounding(t time.Time) time.Duration {
+	if schedule.Delay%time.Second != 0 {
+		return 0
+	}
+	return time.Duration(t.Nanosecond()) * time.Nanosecond
+}
+
+// Previous is the same as Prev, for the Schedule interface.
 func (schedule ConstantDelaySchedule) Previous(t time.Time) time.Time {
-	return t.Add(-schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
+	return schedule.Prev(t)
+}
+
+// String returns the "@every" descriptor for the schedule, e.g. "@every 1h30m".
+func (schedule ConstantDelaySchedule) String() string {
+	return every + formatDuration(schedule.Delay)
+}
+
+// formatDuration returns the duration in the form accepted by @every, without
+// any trailing zero units.
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
 }
@@ -52,3 +52,83 @@ func TestConstantDelayNext(t *testing.T) {
 		}
 	}
 }
+
+func TestConstantDelayPrev(t *testing.T) {
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		expected string
+	}{
+		{"Mon Jul 9 15:00 2012", 15 * time.Minute, "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 15:14:59 2012", 15 * time.Minute, "Mon Jul 9 14:59:59 2012"},
+
+		// Wrap around days
+		{"Tue Jul 10 00:20 2012", 35 * time.Minute, "Mon Jul 9 23:45 2012"},
+
+		// Round to nearest second when calculating the previous time.
+		{"Mon Jul 9 15:00:00.005 2012", 15 * time.Minute, "Mon Jul 9 14:45 2012"},
+	}
+
+	for _, c := range tests {
+		actual := Every(c.delay).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if actual != expected {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.delay, expected, actual)
+		}
+	}
+}
+
+func TestConstantDelayExact(t *testing.T) {
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		expected string
+	}{
+		{"Mon Jul 9 14:45:00 2012", 500 * time.Millisecond, "Mon Jul 9 14:45:00.5 2012"},
+		{"Mon Jul 9 14:45:00.75 2012", 500 * time.Millisecond, "Mon Jul 9 14:45:01.25 2012"},
+		{"Mon Jul 9 14:45:00.005 2012", 15*time.Minute + 50*time.Nanosecond, "Mon Jul 9 15:00:00.00500005 2012"},
+
+		// Whole seconds still round to the second.
+		{"Mon Jul 9 14:45:00.005 2012", 15 * time.Minute, "Mon Jul 9 15:00 2012"},
+
+		// Delays that are not positive round up to a nanosecond.
+		{"Mon Jul 9 14:45:00 2012", 0, "Mon Jul 9 14:45:00.000000001 2012"},
+	}
+
+	for _, c := range tests {
+		schedule := EveryExact(c.delay)
+		from, expected := getTime(c.time), getTime(c.expected)
+		if actual := schedule.Next(from); actual != expected {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.delay, expected, actual)
+		}
+		if c.delay%time.Second != 0 {
+			if actual := schedule.Prev(expected); actual != from {
+				t.Errorf("%s, \"%s\": (expected) %v != %v (actual prev)", c.expected, c.delay, from, actual)
+			}
+		}
+	}
+}
+
+func TestConstantDelayString(t *testing.T) {
+	tests := []struct {
+		delay    time.Duration
+		expected string
+	}{
+		{time.Hour + 30*time.Minute, "@every 1h30m"},
+		{time.Hour, "@every 1h"},
+		{5 * time.Minute, "@every 5m"},
+		{90 * time.Second, "@every 1m30s"},
+		{time.Hour + 10*time.Second, "@every 1h0m10s"},
+		{500 * time.Millisecond, "@every 500ms"},
+	}
+	for _, c := range tests {
+		if actual := EveryExact(c.delay).String(); actual != c.expected {
+			t.Errorf("%v: (expected) %s != %s (actual)", c.delay, c.expected, actual)
+		}
+		if c.delay >= time.Second {
+			if s, err := Parse(c.expected); err != nil || s != Every(c.delay) {
+				t.Errorf("%s => %v, %v", c.expected, s, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package cron
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"unicode"
+)
+
+// CrontabEntry is a job line of a crontab file.
+type CrontabEntry struct {
+	// Schedule is the parsed spec.
+	Schedule Schedule
+
+	// Spec is the spec as written, without the time zone set by a preceding
+	// TZ or CRON_TZ line.
+	Spec string
+
+	// User is the user the command runs as, for crontabs parsed with
+	// WithUserField.
+	User string
+
+	// Command is the rest of the line after the spec (and user).
+	Command string
+
+	// Env holds the environment variables set by the lines preceding the
+	// entry.
+	Env map[string]string
+
+	// Line is the 1-based line number of the entry.
+	Line int
+}
+
+// CrontabError reports a line of a crontab file that could not be parsed.
+type CrontabError struct {
+	Line int
+	Err  error
+}
+
+func (e *CrontabError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *CrontabError) Unwrap() error {
+	return e.Err
+}
+
+// crontabParser holds the options and state of ParseCrontab.
+type crontabParser struct {
+	userField bool
+	skip      bool
+	env       map[string]string
+}
+
+// CrontabOption configures ParseCrontab.
+type CrontabOption func(*crontabParser)
+
+// WithUserField expects the spec of each entry to be followed by the user to
+// run the command as, the way /etc/crontab and the files of /etc/cron.d are
+// written.
+func WithUserField() CrontabOption {
+	return func(p *crontabParser) {
+		p.userField = true
+	}
+}
+
+// SkipInvalidLines continues past lines that cannot be parsed instead of
+// stopping at the first one.  ParseCrontab then returns the entries of the
+// other lines along with an error joining a *CrontabError for each of them.
+func SkipInvalidLines() CrontabOption {
+	return func(p *crontabParser) {
+		p.skip = true
+	}
+}
+
+// ParseCrontab parses a crontab file, returning its entries in order.
+//
+// Each line is blank, a comment starting with "#", an environment setting of
+// the form "NAME=value", or an entry: a standard 5-field spec or a descriptor,
+// followed by the command.  Values of environment settings may be quoted, and
+// apply to the entries that follow them.  TZ and CRON_TZ settings set the time
+// zone of the specs that follow.
+//
+// Unless SkipInvalidLines is given, the first line that cannot be parsed
+// stops parsing and returns a *CrontabError.
+func ParseCrontab(r io.Reader, opts ...CrontabOption) ([]CrontabEntry, error) {
+	p := &crontabParser{env: make(map[string]string)}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	var (
+		entries []CrontabEntry
+		errs    []error
+		scanner = bufio.NewScanner(r)
+	)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if name, value, ok := parseCrontabEnv(line); ok {
+			p.env[name] = value
+			continue
+		}
+		entry, err := p.entry(line)
+		if err != nil {
+			err = &CrontabError{Line: n, Err: err}
+			if !p.skip {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		entry.Line = n
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, errors.Join(errs...)
+}
+
+// parseCrontabEnv returns the name and value of an environment setting, or
+// false if the line is not one.
+func parseCrontabEnv(line string) (name, value string, ok bool) {
+	name, value, ok = strings.Cut(line, "=")
+	name = strings.TrimSpace(name)
+	if !ok || !isCrontabEnvName(name) {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
+
+// isCrontabEnvName returns true if name is a valid environment variable name.
+func isCrontabEnvName(name string) bool {
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		return false
+	}
+	for _, c := range name {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// entry parses an entry line.
+func (p *crontabParser) entry(line string) (CrontabEntry, error) {
+	fields := strings.Fields(line)
+	n := 5
+	if strings.HasPrefix(fields[0], "@") {
+		n = 1
+		if isEvery(fields[0]) {
+			n = 2
+			if len(fields) > 2 && strings.EqualFold(fields[2], "offset") {
+				n = 4
+			}
+		}
+	}
+	if p.userField {
+		n++
+	}
+	if len(fields) <= n {
+		return CrontabEntry{}, fmt.Errorf("missing command: %q", line)
+	}
+
+	specFields := fields[:n]
+	var user string
+	if p.userField {
+		specFields, user = fields[:n-1], fields[n-1]
+	}
+	spec := strings.Join(specFields, " ")
+	tz := p.env["CRON_TZ"]
+	if tz == "" {
+		tz = p.env["TZ"]
+	}
+	parsed := spec
+	if tz != "" {
+		parsed = "TZ=" + tz + " " + spec
+	}
+	sched, err := NewParser(WithoutSeconds(), WithoutYear()).Parse(parsed)
+	if err != nil {
+		return CrontabEntry{}, err
+	}
+
+	command, err := crontabCommand(line, n)
+	if err != nil {
+		return CrontabEntry{}, err
+	}
+	return CrontabEntry{
+		Schedule: sched,
+		Spec:     spec,
+		User:     user,
+		Command:  command,
+		Env:      maps.Clone(p.env),
+	}, nil
+}
+
+// crontabCommand returns the rest of the line after its first n fields,
+// keeping the spacing within the command as written.  Fields are separated by
+// white space as strings.Fields defines it.
+func crontabCommand(line string, n int) (string, error) {
+	rest := line
+	for i := 0; i < n; i++ {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		j := strings.IndexFunc(rest, unicode.IsSpace)
+		if j < 0 {
+			return "", fmt.Errorf("missing command: %q", line)
+		}
+		rest = rest[j:]
+	}
+	return strings.TrimSpace(rest), nil
+}
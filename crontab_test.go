@@ -0,0 +1,119 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCrontab = `# m h dom mon dow command
+SHELL=/bin/sh
+PATH = "/usr/bin:/bin"
+
+30 4 * * *   /usr/bin/backup --full   > /dev/null
+@hourly      echo hello
+CRON_TZ=Asia/Tokyo
+@every 1h offset 15m  date
+0 9 * * MON-FRI   run report
+`
+
+func TestParseCrontab(t *testing.T) {
+	entries, err := ParseCrontab(strings.NewReader(testCrontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		spec, command string
+		line          int
+		env           int
+	}{
+		{"30 4 * * *", "/usr/bin/backup --full   > /dev/null", 5, 2},
+		{"@hourly", "echo hello", 6, 2},
+		{"@every 1h offset 15m", "date", 8, 3},
+		{"0 9 * * MON-FRI", "run report", 9, 3},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("got %d entries, expected %d", len(entries), len(expected))
+	}
+	for i, c := range expected {
+		e := entries[i]
+		if e.Spec != c.spec || e.Command != c.command || e.Line != c.line || len(e.Env) != c.env {
+			t.Errorf("entry %d: %+v, expected %+v", i, e, c)
+		}
+	}
+	if path := entries[0].Env["PATH"]; path != "/usr/bin:/bin" {
+		t.Errorf("PATH = %q, expected quotes to be removed", path)
+	}
+	if _, ok := entries[0].Env["CRON_TZ"]; ok {
+		t.Error("CRON_TZ applied to entries before it")
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	if loc := entries[3].Schedule.(*SpecSchedule).Location; loc.String() != tokyo.String() {
+		t.Errorf("location %v, expected %v", loc, tokyo)
+	}
+	if loc := entries[0].Schedule.(*SpecSchedule).Location; loc != time.Local {
+		t.Errorf("location %v, expected Local", loc)
+	}
+}
+
+func TestParseCrontabUserField(t *testing.T) {
+	entries, err := ParseCrontab(strings.NewReader("17 * * * * root cd / && run-parts /etc/cron.hourly\n"), WithUserField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, expected 1", len(entries))
+	}
+	if e := entries[0]; e.Spec != "17 * * * *" || e.User != "root" || e.Command != "cd / && run-parts /etc/cron.hourly" {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	const crontab = "0 0 * * * ok\n0 0 * *\n61 * * * * bad minute\n@daily fine\n"
+
+	_, err := ParseCrontab(strings.NewReader(crontab))
+	var cerr *CrontabError
+	if !errors.As(err, &cerr) || cerr.Line != 2 || !strings.Contains(err.Error(), "missing command") {
+		t.Errorf("got %v, expected a missing command on line 2", err)
+	}
+
+	entries, err := ParseCrontab(strings.NewReader(crontab), SkipInvalidLines())
+	if len(entries) != 2 || entries[1].Line != 4 {
+		t.Errorf("got %+v, expected the entries of lines 1 and 4", entries)
+	}
+	if err == nil || !strings.Contains(err.Error(), "line 2:") || !strings.Contains(err.Error(), "line 3:") {
+		t.Errorf("got %v, expected errors for lines 2 and 3", err)
+	}
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Field != 1 {
+		t.Errorf("got %v, expected a *ParseError for the minutes", err)
+	}
+}
+
+func TestParseCrontabSeparators(t *testing.T) {
+	for _, sep := range []string{"\f", "\v", "\u00a0", "\u2003", " \t"} {
+		for _, c := range []struct{ line, spec string }{
+			{"@daily" + sep + "echo hi", "@daily"},
+			{"0 0 * * *" + sep + "echo hi", "0 0 * * *"},
+			{"0" + sep + "0 * * *" + sep + "echo hi", "0 0 * * *"},
+		} {
+			entries, err := ParseCrontab(strings.NewReader(c.line))
+			if err != nil {
+				t.Errorf("%q => unexpected error: %v", c.line, err)
+				continue
+			}
+			if len(entries) != 1 || entries[0].Spec != c.spec || entries[0].Command != "echo hi" {
+				t.Errorf("%q => got %+v", c.line, entries)
+			}
+		}
+	}
+
+	for _, line := range []string{"@daily\f", "0 0 * * *\v", "0 0 * * *\u00a0"} {
+		if _, err := ParseCrontab(strings.NewReader(line)); err == nil || !strings.Contains(err.Error(), "missing command") {
+			t.Errorf("%q => got %v, expected a missing command", line, err)
+		}
+	}
+}
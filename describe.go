@@ -0,0 +1,283 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Describe returns an English description of the schedule, e.g. "At 04:05 on
+// Sunday in June" for "5 4 * JUN SUN".  It returns an error for schedules it
+// does not know how to describe.
+func Describe(s Schedule) (string, error) {
+	return english.describe(s)
+}
+
+// phrases holds the words that descriptions are made of, so that they may be
+// given in other languages.
+type phrases struct {
+	units    [7][2]string // singular and plural name of each field's unit
+	months   [12]string
+	days     [7]string
+	ordinals [5]string // "first" to "fifth"
+
+	every      string // "every minute"
+	everyN     string // "every 15 minutes"
+	fromTo     string // "every minute from 9 through 17"
+	through    string // "9 through 17"
+	and        string // "0, 15 and 30"
+	or         string // "on day-of-month 13 or on Friday"
+	at         string // "at minute 5"
+	past       string // "minute 5 past hour 9"
+	on         string // "on Sunday"
+	in         string // "in June"
+	clock      string // "04:05"
+	clockSecs  string // "04:05:30"
+	lastDay    string
+	lastWeek   string // last weekday of the month
+	nearest    string // weekday nearest to a day
+	nth        string // "the second Monday"
+	lastDow    string // "the last Friday"
+	location   string // "in time zone Asia/Tokyo"
+	interval   string // "every 1h30m"
+	aligned    string // "every 1h, offset 15m from the Unix epoch"
+	onStart    string
+	once       string // "once, at 2012-07-09T14:45:30Z"
+	cannotDesc string
+}
+
+var english = &phrases{
+	units: [7][2]string{
+		{"second", "seconds"},
+		{"minute", "minutes"},
+		{"hour", "hours"},
+		{"day-of-month", "days-of-month"},
+		{"month", "months"},
+		{"day-of-week", "days-of-week"},
+		{"year", "years"},
+	},
+	months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	days:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ordinals: [5]string{"first", "second", "third", "fourth", "fifth"},
+
+	every:      "every %s",
+	everyN:     "every %d %s",
+	fromTo:     "%s from %s through %s",
+	through:    "%s through %s",
+	and:        " and ",
+	or:         " or ",
+	at:         "at %s",
+	past:       " past ",
+	on:         "on %s",
+	in:         "in %s",
+	clock:      "%02d:%02d",
+	clockSecs:  "%02d:%02d:%02d",
+	lastDay:    "the last day of the month",
+	lastWeek:   "the last weekday of the month",
+	nearest:    "the weekday nearest day %d",
+	nth:        "the %s %s",
+	lastDow:    "the last %s",
+	location:   "in time zone %s",
+	interval:   "every %s",
+	aligned:    "every %s, offset %s from the Unix epoch",
+	onStart:    "once, when the scheduler starts",
+	once:       "once, at %s",
+	cannotDesc: "cannot describe schedule of type %T",
+}
+
+// describe returns the description of the schedule in these phrases.
+func (p *phrases) describe(s Schedule) (string, error) {
+	var desc string
+	switch s := s.(type) {
+	case *SpecSchedule:
+		desc = p.describeSpec(s)
+	case ConstantDelaySchedule:
+		desc = fmt.Sprintf(p.interval, formatDuration(s.Delay))
+	case AlignedDelaySchedule:
+		desc = fmt.Sprintf(p.aligned, formatDuration(s.Period), formatDuration(s.Offset))
+	case *OnStartSchedule:
+		desc = p.onStart
+	case OnceSchedule:
+		desc = fmt.Sprintf(p.once, s.At.Format(time.RFC3339))
+	default:
+		return "", fmt.Errorf(p.cannotDesc, s)
+	}
+	return strings.ToUpper(desc[:1]) + desc[1:], nil
+}
+
+// describeSpec returns the description of a crontab schedule.
+func (p *phrases) describeSpec(s *SpecSchedule) string {
+	var clauses []string
+	if clause := p.describeTime(s); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	if clause := p.describeDays(s); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	if len(s.Months()) < 12 {
+		clauses = append(clauses, fmt.Sprintf(p.in, p.field(MonthField, bitValues(s.Month), true)))
+	}
+	if years := s.Years(); years != nil {
+		clauses = append(clauses, fmt.Sprintf(p.in, p.field(YearField, years, false)))
+	}
+	if s.Location != nil && s.Location.String() != "Local" {
+		clauses = append(clauses, fmt.Sprintf(p.location, s.Location))
+	}
+	return strings.Join(clauses, " ")
+}
+
+// describeTime returns the clause describing the time of day.
+func (p *phrases) describeTime(s *SpecSchedule) string {
+	secs, mins, hours := s.Seconds(), s.Minutes(), s.Hours()
+	if len(secs) == 1 && len(mins) == 1 && len(hours) == 1 {
+		if secs[0] == 0 {
+			return fmt.Sprintf(p.at, fmt.Sprintf(p.clock, hours[0], mins[0]))
+		}
+		return fmt.Sprintf(p.at, fmt.Sprintf(p.clockSecs, hours[0], mins[0], secs[0]))
+	}
+
+	var parts []string
+	if len(secs) != 1 || secs[0] != 0 {
+		parts = append(parts, p.field(SecondField, secs, false))
+	}
+	if len(mins) < 60 || len(parts) == 0 {
+		parts = append(parts, p.field(MinuteField, mins, false))
+	}
+	if len(hours) < 24 {
+		parts = append(parts, p.field(HourField, hours, false))
+	}
+	// Clauses that begin with "every" read better without "at".
+	clause := strings.Join(parts, p.past)
+	if strings.HasPrefix(clause, strings.SplitN(p.every, "%s", 2)[0]) {
+		return clause
+	}
+	return fmt.Sprintf(p.at, clause)
+}
+
+// describeDays returns the clause describing the days of the month and of the
+// week, if they are restricted.
+func (p *phrases) describeDays(s *SpecSchedule) string {
+	var domItems, dowItems []string
+	if values := s.DaysOfMonth(); len(values) > 0 && len(values) < 31 {
+		domItems = append(domItems, p.field(DomField, values, false))
+	}
+	for _, day := range bitValues(s.DomWeekday) {
+		domItems = append(domItems, fmt.Sprintf(p.nearest, day))
+	}
+	if s.DomLast {
+		domItems = append(domItems, p.lastDay)
+	}
+	if s.DomLastWeekday {
+		domItems = append(domItems, p.lastWeek)
+	}
+	if values := bitValues(s.Dow); len(values) > 0 && len(values) < 7 {
+		dowItems = append(dowItems, p.field(DowField, values, true))
+	}
+	for _, bit := range bitValues(s.DowNth) {
+		dowItems = append(dowItems, fmt.Sprintf(p.nth, p.ordinals[bit/7], p.days[bit%7]))
+	}
+	for _, day := range bitValues(s.DowLast) {
+		dowItems = append(dowItems, fmt.Sprintf(p.lastDow, p.days[day]))
+	}
+
+	// A day field with a star matches every day, so that only the other
+	// restricts the schedule.
+	var clauses []string
+	if len(domItems) > 0 && (s.Dom&starBit == 0 || len(dowItems) == 0) {
+		clauses = append(clauses, fmt.Sprintf(p.on, p.list(domItems)))
+	}
+	if len(dowItems) > 0 && (s.Dow&starBit == 0 || len(domItems) == 0) {
+		clauses = append(clauses, fmt.Sprintf(p.on, p.list(dowItems)))
+	}
+	if s.DomDow == DomAndDow {
+		return strings.Join(clauses, p.and)
+	}
+	return strings.Join(clauses, p.or)
+}
+
+// field describes the given ordered values of a field.  If named is set, the
+// values are named (as months or days of the week) rather than numbered.
+func (p *phrases) field(f Field, values []int, named bool) string {
+	r := fieldBounds[f]
+	unit, units := p.units[f][0], p.units[f][1]
+	name := func(v int) string {
+		switch {
+		case named && f == MonthField:
+			return p.months[v-1]
+		case named && f == DowField:
+			return p.days[v]
+		}
+		return fmt.Sprint(v)
+	}
+
+	if len(values) == int(r.max-r.min+1) {
+		return fmt.Sprintf(p.every, unit)
+	}
+	if len(values) == 1 {
+		if named {
+			return name(values[0])
+		}
+		return unit + " " + name(values[0])
+	}
+
+	// Evenly spaced values, except for days of the week, which read better
+	// as a list.
+	step := values[1] - values[0]
+	if len(values) >= 3 && isProgression(values, step) && (step == 1 || f != DowField) {
+		first, last := values[0], values[len(values)-1]
+		if named && step == 1 {
+			return fmt.Sprintf(p.through, name(first), name(last))
+		}
+		every := fmt.Sprintf(p.every, unit)
+		if step > 1 {
+			every = fmt.Sprintf(p.everyN, step, units)
+		}
+		if step > 1 && first == int(r.min) && last+step > int(r.max) {
+			return every
+		}
+		return fmt.Sprintf(p.fromTo, every, name(first), name(last))
+	}
+
+	// A list of values and ranges.
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, fmt.Sprintf(p.through, name(values[i]), name(values[j])))
+		} else {
+			for ; i < j; i++ {
+				items = append(items, name(values[i]))
+			}
+			items = append(items, name(values[j]))
+		}
+		i = j + 1
+	}
+	if named {
+		return p.list(items)
+	}
+	return units + " " + p.list(items)
+}
+
+// list joins the items as "a, b and c".
+func (p *phrases) list(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + p.and + items[len(items)-1]
+}
+
+// isProgression returns true if the ordered values are evenly spaced by step.
+func isProgression(values []int, step int) bool {
+	for i := 1; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return false
+		}
+	}
+	return true
+}
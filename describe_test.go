@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"5 4 * JUN SUN", "At 04:05 on Sunday in June"},
+		{"30 5 4 * * *", "At 04:05:30"},
+		{"* * * * *", "Every minute"},
+		{"* * * * * *", "Every second"},
+		{"*/15 * * * *", "Every 15 minutes"},
+		{"5/15 * * * *", "Every 15 minutes from 5 through 50"},
+		{"30 * * * * *", "At second 30"},
+		{"5 9-17 * * *", "At minute 5 past every hour from 9 through 17"},
+		{"0,30 9 * * *", "At minutes 0 and 30 past hour 9"},
+		{"0 0 */2 * * *", "At minute 0 past every 2 hours"},
+		{"0 1,2,3,5 * * *", "At minute 0 past hours 1 through 3 and 5"},
+		{"0 9 * * MON-FRI", "At 09:00 on Monday through Friday"},
+		{"0 9 * * 1,3,5", "At 09:00 on Monday, Wednesday and Friday"},
+		{"0 9 * JAN,JUL *", "At 09:00 in January and July"},
+		{"0 9 * */3 *", "At 09:00 in every 3 months"},
+		{"0 9 1,15 * *", "At 09:00 on days-of-month 1 and 15"},
+		{"0 9 13 * FRI", "At 09:00 on day-of-month 13 or on Friday"},
+		{"0 9 L * *", "At 09:00 on the last day of the month"},
+		{"0 9 15W,LW * *", "At 09:00 on the weekday nearest day 15 and the last weekday of the month"},
+		{"0 9 ? * MON#2", "At 09:00 on the second Monday"},
+		{"0 9 ? * FRIL", "At 09:00 on the last Friday"},
+		{"0 0 12 1 1 ? 2027", "At 12:00 on day-of-month 1 in January in year 2027"},
+		{"TZ=Asia/Tokyo 30 4 * * *", "At 04:30 in time zone Asia/Tokyo"},
+		{"@daily", "At 00:00"},
+		{"@every 1h30m", "Every 1h30m"},
+		{"@every 1h offset 15m", "Every 1h, offset 15m from the Unix epoch"},
+		{"@reboot", "Once, when the scheduler starts"},
+	}
+	for _, c := range tests {
+		actual, err := Describe(MustParse(c.spec))
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s => (expected) %q != %q (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	and, err := NewParser(WithDomDowPolicy(DomAndDow)).Parse("0 9 13 * FRI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, _ := Describe(and); actual != "At 09:00 on day-of-month 13 and on Friday" {
+		t.Errorf("DomAndDow => %q", actual)
+	}
+
+	at := time.Date(2012, 7, 9, 14, 45, 30, 0, time.UTC)
+	if actual, _ := Describe(Once(at)); actual != "Once, at 2012-07-09T14:45:30Z" {
+		t.Errorf("Once => %q", actual)
+	}
+
+	if _, err := Describe(Union(Every(time.Hour))); err == nil {
+		t.Error("expected an error describing a union")
+	}
+}
@@ -25,19 +25,24 @@ them in their own goroutines.
 
 CRON Expression Format
 
-A cron expression represents a set of times, using 6 space-separated fields.
+A cron expression represents a set of times, using 6 space-separated fields,
+optionally followed by a 7th year field.
 
 	Field name   | Mandatory? | Allowed values  | Allowed special characters
 	----------   | ---------- | --------------  | --------------------------
 	Seconds      | No         | 0-59            | * / , -
 	Minutes      | Yes        | 0-59            | * / , -
 	Hours        | Yes        | 0-23            | * / , -
-	Day of month | Yes        | 1-31            | * / , - ?
+	Day of month | Yes        | 1-31            | * / , - ? L W
 	Month        | Yes        | 1-12 or JAN-DEC | * / , -
-	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ?
+	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ? # L
+	Year         | No         | 1970-2099       | * / , -
 
 Note: Month and Day-of-week field values are case insensitive.  "SUN", "Sun",
-and "sun" are equally accepted.
+and "sun" are equally accepted.  Sunday may also be given as 7 in the
+Day-of-week field, so "5-7" stands for Friday through Sunday.  Parsers created
+with WithLenientNames also accept full names, such as "January" and "Monday",
+and the abbreviations "Sept", "Tues", "Thur" and "Thurs".
 
 Special Characters
 
@@ -55,22 +60,76 @@ minutes thereafter. The form "*\/..." is equivalent to the form "first-last/..."
 that is, an increment over the largest possible range of the field.  The form
 "N/..." is accepted as meaning "N-MAX/...", that is, starting at N, use the
 increment until the end of that specific range.  It does not wrap around.
+Month and day-of-week names may be used in place of numbers, e.g. "JAN/3" for
+every third month starting in January, or "MON-FRI/2".
+The step must be a positive integer.  A step larger than the range selects its
+beginning only, e.g. "*\/100" in the minutes field stands for minute 0.
 
 Comma ( , )
 
 Commas are used to separate items of a list. For example, using "MON,WED,FRI" in
-the 5th field (day of week) would mean Mondays, Wednesdays and Fridays.
+the 5th field (day of week) would mean Mondays, Wednesdays and Fridays.  Items
+may not be empty, and there may be no spaces around the commas, so "1,,5",
+"5," and "1, 5" are all rejected.
 
 Hyphen ( - )
 
 Hyphens are used to define ranges. For example, 9-17 would indicate every
 hour between 9am and 5pm inclusive.
 
+A range whose beginning is beyond its end wraps around the end of the field.
+For example, 22-2 in the hours field would indicate 10pm through 2am, and
+FRI-MON/2 in the day-of-week field would indicate Friday and Sunday.  Parsers
+created with WithStrictRanges reject such ranges instead.
+
 Question mark ( ? )
 
 Question mark may be used instead of '*' for leaving either day-of-month or
 day-of-week blank.
 
+When both day-of-month and day-of-week are restricted, a day matching either
+of them activates the schedule, as in classic cron: "0 0 13 * FRI" runs on the
+13th and on every Friday.  Parsers created with WithDomDowPolicy(DomAndDow)
+require both to match instead, so that it runs on Friday the 13th only, as
+does a spec prefixed by DOMDOW=AND, e.g. "DOMDOW=AND 0 0 13 * FRI".  The
+prefix may be combined with a time zone prefix in either order, and
+DOMDOW=OR selects the classic behavior whatever the parser's policy.
+
+L
+
+In the day-of-month field, L stands for the last day of the month: the 31st of
+January, the 28th of February in common years and the 29th in leap years, and
+so on.  It may be combined with other values in a list, e.g. "15,L".
+
+In the day-of-week field, L after a day stands for the last occurrence of that
+day within the month, e.g. "FRIL" or "5L" for the last Friday of the month.  A
+bare L is not accepted in the day-of-week field.
+
+W
+
+In the day-of-month field, W after a day stands for the weekday (Monday to
+Friday) nearest to that day.  For example "15W" activates on Friday the 14th if
+the 15th is a Saturday, and on Monday the 16th if the 15th is a Sunday.  The
+nearest weekday never falls in another month, so "1W" activates on Monday the
+3rd if the 1st is a Saturday.  "LW" stands for the last weekday of the month.
+W applies to a single day only; it may not be used with a range or step.
+
+Number sign ( # )
+
+In the day-of-week field, "D#N" stands for the Nth occurrence of day D within
+the month, where N is between 1 and 5.  For example "MON#2" activates on the
+second Monday of each month.  Months without a fifth occurrence of a day are
+skipped by "D#5".
+
+H
+
+Specs parsed with ParseWithHash may use H in any field to stand for a value
+chosen by hashing a caller-supplied seed, so that many schedules with the same
+spec spread their activations out instead of all running at once.  "H" stands
+for a single value in the field, "H(N-M)" for a single value within N-M, and
+"H/step" for every step starting at a hashed offset.  For example "H H * * *"
+runs once a day, at a time that is the same every day for a given seed.
+
 Predefined schedules
 
 You may use one of several pre-defined schedules in place of a cron expression.
@@ -82,6 +141,12 @@ You may use one of several pre-defined schedules in place of a cron expression.
 	@weekly                | Run once a week, midnight on Sunday        | 0 0 0 * * 0
 	@daily (or @midnight)  | Run once a day, midnight                   | 0 0 0 * * *
 	@hourly                | Run once an hour, beginning of hour        | 0 0 * * * *
+	@minutely              | Run once a minute, beginning of minute     | 0 * * * * *
+	@weekday               | Run once a day, midnight, Monday to Friday | 0 0 0 * * MON-FRI
+	@weekend               | Run once a day, midnight, on weekends      | 0 0 0 * * SAT,SUN
+	@reboot                | Run once, when the scheduler starts        |
+
+Descriptor names are case insensitive, so "@Daily" is the same as "@daily".
 
 Intervals
 
@@ -91,11 +156,23 @@ formatting the cron spec like this:
     @every <duration>
 
 where "duration" is a string accepted by time.ParseDuration
-(http://golang.org/pkg/time/#ParseDuration).
+(http://golang.org/pkg/time/#ParseDuration).  The units "d" and "w" are
+accepted as well, e.g. "@every 1w2d12h".  They stand for a fixed 24 and 168
+hours, not calendar days and weeks, so they drift across daylight savings
+transitions.
 
 For example, "@every 1h30m10s" would indicate a schedule that activates every
 1 hour, 30 minutes, 10 seconds.
 
+Such a schedule activates relative to when it was first consulted.  To anchor
+the interval instead, so that it activates at the same times wherever it runs,
+add an offset from the Unix epoch:
+
+    @every <duration> offset <duration>
+
+For example, "@every 1h offset 15m" activates at a quarter past every hour (in
+UTC), and "@every 1d offset 2h" at 2am UTC every day.
+
 Note: The interval does not take the job runtime into account.  For example,
 if a job takes 3 minutes to run, and it is scheduled to run every 5 minutes,
 it will have only 2 minutes of idle time between each run.
@@ -105,10 +182,23 @@ Time zones
 By default, all interpretation and scheduling is done in the machine's local
 time zone (as provided by the Go time package http://www.golang.org/pkg/time).
 The time zone may be overridden by providing an additional space-separated field
-at the beginning of the cron spec, of the form "TZ=Asia/Tokyo"
-
-Be aware that jobs scheduled during daylight-savings leap-ahead transitions will
-not be run!
+at the beginning of the cron spec, of the form "TZ=Asia/Tokyo".  The form
+"CRON_TZ=Asia/Tokyo" is accepted as well, and the zone name may be quoted, as
+in TZ="Asia/Tokyo".  Parsers created with WithLocation use the given time zone
+instead of the local one for specs without a prefix.
+
+Jobs scheduled at wall clock times that a daylight-savings leap-ahead
+transition skips are run once, when the skipped interval ends.  Parsers
+created with WithGapPolicy(SkipGap) skip them instead, as do specs prefixed
+by GAP=SKIP, e.g.
+
+	TZ=America/New_York GAP=SKIP 30 2 * * *
+
+Jobs scheduled at wall clock times that a fall-back transition repeats are run
+once, at the first occurrence, unless they are scheduled in every hour.
+Parsers created with WithRepeatPolicy(RunBothRepeats) run them at both
+occurrences, as do specs prefixed by REPEAT=BOTH.  Intervals given with @every
+are measured in elapsed time, so are unaffected.
 
 Thread safety
 
@@ -0,0 +1,122 @@
+package cron
+
+import "time"
+
+// GapPolicy selects what a SpecSchedule does with activations at wall clock
+// times that do not exist because a daylight savings transition skips them,
+// such as 02:30 on the day clocks in New York spring forward from 02:00 to
+// 03:00.
+type GapPolicy int
+
+const (
+	// RunAfterGap activates once at the end of a skipped interval if the
+	// schedule matches any wall clock time within it, e.g. at 03:00 for a
+	// schedule at 02:30 in the example above.  This is the default.
+	RunAfterGap GapPolicy = iota
+
+	// SkipGap drops activations within a skipped interval, so that a
+	// schedule at 02:30 does not activate on the day of the transition.
+	SkipGap
+)
+
+// RepeatPolicy selects what a SpecSchedule does with activations at wall clock
+// times that occur twice because a daylight savings transition repeats them,
+// such as 01:30 on the day clocks in New York fall back from 02:00 to 01:00.
+type RepeatPolicy int
+
+const (
+	// RunFirstRepeat activates at the first occurrence of a repeated wall
+	// clock time only, so that a schedule at 01:30 activates once on the day
+	// of the transition.  Schedules that activate in every hour are exempt,
+	// as skipping the repeated hour would leave a gap in them instead; they
+	// keep activating throughout it.  This is the default.
+	RunFirstRepeat RepeatPolicy = iota
+
+	// RunBothRepeats activates at both occurrences of a repeated wall clock
+	// time.
+	RunBothRepeats
+)
+
+// zoneTransitionLimit bounds the number of time zone transitions examined when
+// looking for skipped intervals, which is ample for the years a SpecSchedule
+// searches.
+const zoneTransitionLimit = 1000
+
+// nextAfterGap returns the end of the first skipped interval after t and
+// before next in which the schedule matches a wall clock time, or next if
+// there is none.
+func (s *SpecSchedule) nextAfterGap(t, next time.Time) time.Time {
+	if next.IsZero() {
+		return next
+	}
+	cur := t.In(s.Location)
+	for i := 0; i < zoneTransitionLimit; i++ {
+		_, end := cur.ZoneBounds()
+		if end.IsZero() || !end.Before(next) {
+			break
+		}
+		if s.matchesInGap(end) {
+			return end.In(t.Location())
+		}
+		cur = end
+	}
+	return next
+}
+
+// prevAfterGap returns the end of the last skipped interval before t and after
+// prev in which the schedule matches a wall clock time, or prev if there is
+// none.
+func (s *SpecSchedule) prevAfterGap(t, prev time.Time) time.Time {
+	if prev.IsZero() {
+		return prev
+	}
+	cur := t.In(s.Location)
+	for i := 0; i < zoneTransitionLimit; i++ {
+		start, _ := cur.Add(-time.Nanosecond).ZoneBounds()
+		if start.IsZero() || !start.After(prev) {
+			break
+		}
+		if s.matchesInGap(start) {
+			return start.In(t.Location())
+		}
+		cur = start
+	}
+	return prev
+}
+
+// matchesInGap returns true if the zone transition at the given time skips
+// wall clock times and the schedule matches any of them.
+func (s *SpecSchedule) matchesInGap(transition time.Time) bool {
+	_, before := transition.Add(-time.Nanosecond).Zone()
+	_, after := transition.Zone()
+	if after <= before {
+		return false
+	}
+
+	// Within a fixed zone at the earlier offset, the skipped wall clock times
+	// fall between the transition and the length of the gap after it.
+	fixed := s.WithLocation(time.FixedZone("", before))
+	fixed.Gap = SkipGap
+	match := fixed.next(transition.Add(-time.Second))
+	gapEnd := transition.Add(time.Duration(after-before) * time.Second)
+	return !match.IsZero() && match.Before(gapEnd)
+}
+
+// repeated returns true if t is the second occurrence of a wall clock time
+// that a zone transition repeats, along with the start and end of the
+// repeated interval in which it falls.  Schedules activating in every hour are
+// never considered repeated; see RunFirstRepeat.
+func (s *SpecSchedule) repeated(t time.Time) (start, end time.Time, ok bool) {
+	if t.IsZero() || s.Hour&^starBit == getBits(hours.min, hours.max, 1) {
+		return time.Time{}, time.Time{}, false
+	}
+	t = t.In(s.Location)
+	start, _ = t.ZoneBounds()
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	_, before := start.Add(-time.Nanosecond).Zone()
+	_, after := start.Zone()
+	end = start.Add(time.Duration(before-after) * time.Second)
+	return start, end, before > after && t.Before(end)
+}
@@ -0,0 +1,176 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGapPolicy(t *testing.T) {
+	tests := []struct {
+		spec, from string
+		runAfter   string
+		skip       string
+	}{
+		// US: 2am EST (-5) -> 3am EDT (-4)
+		{"TZ=America/New_York 0 30 2 * * *", "2024-03-10T00:00:00-0500", "2024-03-10T03:00:00-0400", "2024-03-11T02:30:00-0400"},
+		{"TZ=America/New_York 0 */15 2 * * *", "2024-03-10T00:00:00-0500", "2024-03-10T03:00:00-0400", "2024-03-11T02:00:00-0400"},
+		{"TZ=America/New_York 0 30 3 * * *", "2024-03-10T00:00:00-0500", "2024-03-10T03:30:00-0400", "2024-03-10T03:30:00-0400"},
+
+		// EU: 2am CET (+1) -> 3am CEST (+2)
+		{"TZ=Europe/Berlin 0 30 2 * * *", "2024-03-31T00:00:00+0100", "2024-03-31T03:00:00+0200", "2024-04-01T02:30:00+0200"},
+		{"TZ=Europe/Berlin 0 30 2 * * SUN", "2024-03-30T00:00:00+0100", "2024-03-31T03:00:00+0200", "2024-04-07T02:30:00+0200"},
+
+		// Lord Howe: 2am LHST (+10:30) -> 2:30am LHDT (+11)
+		{"TZ=Australia/Lord_Howe 0 15 2 * * *", "2024-10-06T00:00:00+1030", "2024-10-06T02:30:00+1100", "2024-10-07T02:15:00+1100"},
+		{"TZ=Australia/Lord_Howe 0 45 2 * * *", "2024-10-06T00:00:00+1030", "2024-10-06T02:45:00+1100", "2024-10-06T02:45:00+1100"},
+	}
+	for _, c := range tests {
+		runAfter := MustParse(c.spec).(*SpecSchedule)
+		skip := runAfter.WithLocation(runAfter.Location)
+		skip.Gap = SkipGap
+		from := getTime(c.from)
+
+		if next := runAfter.Next(from); !next.Equal(getTime(c.runAfter)) {
+			t.Errorf("%s from %s: (expected) %s != %v (actual)", c.spec, c.from, c.runAfter, next)
+		}
+		if next := skip.Next(from); !next.Equal(getTime(c.skip)) {
+			t.Errorf("%s from %s skipping: (expected) %s != %v (actual)", c.spec, c.from, c.skip, next)
+		}
+
+		// Prev from just after the next activation finds it again.
+		next := getTime(c.runAfter)
+		if prev := runAfter.Prev(next.Add(time.Second)); !prev.Equal(next) {
+			t.Errorf("%s before %s: (expected) %s != %v (actual)", c.spec, next, c.runAfter, prev)
+		}
+	}
+}
+
+func TestWithGapPolicy(t *testing.T) {
+	for _, spec := range []string{"TZ=America/New_York 0 30 2 * * *", "TZ=America/New_York @daily"} {
+		sched, err := NewParser(WithGapPolicy(SkipGap)).Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gap := sched.(*SpecSchedule).Gap; gap != SkipGap {
+			t.Errorf("%s => gap policy %v, expected SkipGap", spec, gap)
+		}
+	}
+
+	a := MustParse("TZ=America/New_York 0 30 2 * * *")
+	b, _ := NewParser(WithGapPolicy(SkipGap)).Parse("TZ=America/New_York 0 30 2 * * *")
+	if Equal(a, b) {
+		t.Error("schedules with different gap policies are equal")
+	}
+
+	// The policy is given by a prefix, which String renders and Parse reads.
+	str := b.(*SpecSchedule).String()
+	if str != "TZ=America/New_York GAP=SKIP 0 30 2 * * *" {
+		t.Errorf("String: %q", str)
+	}
+	for _, spec := range []string{str, "GAP=skip TZ=America/New_York 0 30 2 * * *"} {
+		reparsed, err := Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Equal(reparsed, b) {
+			t.Errorf("%s => (expected) %v != %v (actual)", spec, b, reparsed)
+		}
+	}
+	if sched, _ := NewParser(WithGapPolicy(SkipGap)).Parse("GAP=AFTER 0 30 2 * * *"); sched.(*SpecSchedule).Gap != RunAfterGap {
+		t.Error("GAP=AFTER does not override WithGapPolicy(SkipGap)")
+	}
+}
+
+func TestRepeatPolicy(t *testing.T) {
+	tests := []struct {
+		spec, from string
+		first      []string
+		both       []string
+	}{
+		// US: 2am EDT (-4) -> 1am EST (-5)
+		{"TZ=America/New_York 0 30 1 * * *", "2024-11-03T00:00:00-0400",
+			[]string{"2024-11-03T01:30:00-0400", "2024-11-04T01:30:00-0500"},
+			[]string{"2024-11-03T01:30:00-0400", "2024-11-03T01:30:00-0500"}},
+		{"TZ=America/New_York 0 */20 1 * * *", "2024-11-03T00:00:00-0400",
+			[]string{"2024-11-03T01:00:00-0400", "2024-11-03T01:20:00-0400", "2024-11-03T01:40:00-0400", "2024-11-04T01:00:00-0500"},
+			[]string{"2024-11-03T01:00:00-0400", "2024-11-03T01:20:00-0400", "2024-11-03T01:40:00-0400", "2024-11-03T01:00:00-0500"}},
+
+		// Schedules activating every hour keep activating in the repeated hour.
+		{"TZ=America/New_York 0 30 * * * *", "2024-11-03T00:00:00-0400",
+			[]string{"2024-11-03T00:30:00-0400", "2024-11-03T01:30:00-0400", "2024-11-03T01:30:00-0500"},
+			[]string{"2024-11-03T00:30:00-0400", "2024-11-03T01:30:00-0400", "2024-11-03T01:30:00-0500"}},
+
+		// EU: 3am CEST (+2) -> 2am CET (+1)
+		{"TZ=Europe/Berlin 0 30 2 * * *", "2024-10-27T00:00:00+0200",
+			[]string{"2024-10-27T02:30:00+0200", "2024-10-28T02:30:00+0100"},
+			[]string{"2024-10-27T02:30:00+0200", "2024-10-27T02:30:00+0100"}},
+
+		// Lord Howe: 2am LHDT (+11) -> 1:30am LHST (+10:30)
+		{"TZ=Australia/Lord_Howe 0 45 1 * * *", "2024-04-07T00:00:00+1100",
+			[]string{"2024-04-07T01:45:00+1100", "2024-04-08T01:45:00+1030"},
+			[]string{"2024-04-07T01:45:00+1100", "2024-04-07T01:45:00+1030"}},
+	}
+	for _, c := range tests {
+		first := MustParse(c.spec).(*SpecSchedule)
+		both := first.WithLocation(first.Location)
+		both.Repeat = RunBothRepeats
+
+		for _, p := range []struct {
+			sched    *SpecSchedule
+			expected []string
+		}{{first, c.first}, {both, c.both}} {
+			actual := NextN(p.sched, getTime(c.from), len(p.expected))
+			for i, e := range p.expected {
+				if !actual[i].Equal(getTime(e)) {
+					t.Errorf("%s (repeat policy %v) activation %d: (expected) %s != %v (actual)", c.spec, p.sched.Repeat, i, e, actual[i])
+				}
+			}
+
+			// Prev walks the same activations backwards.
+			last := len(p.expected) - 1
+			for i := last; i > 0; i-- {
+				if prev := p.sched.Prev(getTime(p.expected[i])); !prev.Equal(getTime(p.expected[i-1])) {
+					t.Errorf("%s (repeat policy %v) before %s: (expected) %s != %v (actual)", c.spec, p.sched.Repeat, p.expected[i], p.expected[i-1], prev)
+				}
+			}
+		}
+	}
+}
+
+func TestWithRepeatPolicy(t *testing.T) {
+	sched, err := NewParser(WithRepeatPolicy(RunBothRepeats)).Parse("TZ=America/New_York 0 30 1 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repeat := sched.(*SpecSchedule).Repeat; repeat != RunBothRepeats {
+		t.Errorf("repeat policy %v, expected RunBothRepeats", repeat)
+	}
+	if Equal(sched, MustParse("TZ=America/New_York 0 30 1 * * *")) {
+		t.Error("schedules with different repeat policies are equal")
+	}
+
+	// The policy is given by a prefix, which String renders and Parse reads.
+	str := sched.(*SpecSchedule).String()
+	if str != "TZ=America/New_York REPEAT=BOTH 0 30 1 * * *" {
+		t.Errorf("String: %q", str)
+	}
+	for _, spec := range []string{str, "REPEAT=both TZ=America/New_York 0 30 1 * * *"} {
+		reparsed, err := Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Equal(reparsed, sched) {
+			t.Errorf("%s => (expected) %v != %v (actual)", spec, sched, reparsed)
+		}
+	}
+	if first, _ := NewParser(WithRepeatPolicy(RunBothRepeats)).Parse("REPEAT=FIRST 0 30 1 * * *"); first.(*SpecSchedule).Repeat != RunFirstRepeat {
+		t.Error("REPEAT=FIRST does not override WithRepeatPolicy(RunBothRepeats)")
+	}
+
+	// @every schedules measure elapsed time, so are unaffected.
+	every := MustParse("@every 1h")
+	from := getTime("2024-11-03T00:30:00-0400")
+	if next := every.Next(every.Next(from)); !next.Equal(from.Add(2 * time.Hour)) {
+		t.Errorf("@every 1h across fall-back: %v", next)
+	}
+}
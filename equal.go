@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"reflect"
+)
+
+// Equal returns true if the two schedules activate at the same times because
+// they are of the same kind and describe the same thing, e.g. "0 0 * * *",
+// "@daily" and "0 0 0 * * *".  SpecSchedules are equal if they match the same
+// times in locations of the same name, regardless of whether their fields
+// were written with a star.  Schedules of other types are compared
+// structurally.
+func Equal(a, b Schedule) bool {
+	switch a := a.(type) {
+	case *SpecSchedule:
+		b, ok := b.(*SpecSchedule)
+		return ok && a.normalized() == b.normalized()
+	case ConstantDelaySchedule:
+		b, ok := b.(ConstantDelaySchedule)
+		return ok && a == b
+	case AlignedDelaySchedule:
+		b, ok := b.(AlignedDelaySchedule)
+		return ok && a == b
+	case OnceSchedule:
+		b, ok := b.(OnceSchedule)
+		return ok && a.At.Equal(b.At)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Hash returns a hash of the schedule such that schedules that are Equal have
+// the same hash.
+func Hash(s Schedule) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(reflect.TypeOf(s).String()))
+	write := func(values ...uint64) {
+		for _, v := range values {
+			binary.Write(h, binary.LittleEndian, v)
+		}
+	}
+	switch s := s.(type) {
+	case *SpecSchedule:
+		n := s.normalized()
+		write(n.second, n.minute, n.hour, n.dom, n.month, n.dow, n.domWeekday, n.dowNth, n.dowLast)
+		write(n.year[:]...)
+		if n.and {
+			write(1)
+		}
+		if n.domLast {
+			write(2)
+		}
+		if n.domLastWeekday {
+			write(3)
+		}
+		if n.gap != RunAfterGap {
+			write(4, uint64(n.gap))
+		}
+		if n.repeat != RunFirstRepeat {
+			write(5, uint64(n.repeat))
+		}
+		h.Write([]byte(n.location))
+	case ConstantDelaySchedule:
+		write(uint64(s.Delay))
+	case AlignedDelaySchedule:
+		write(uint64(s.Period), uint64(s.Offset))
+	case OnceSchedule:
+		write(uint64(s.At.UnixNano()))
+	}
+	return h.Sum64()
+}
+
+// normalizedSpec is a comparable form of a SpecSchedule, in which schedules
+// that match the same times are identical.
+type normalizedSpec struct {
+	second, minute, hour, dom, month, dow uint64
+	domLast, domLastWeekday               bool
+	domWeekday, dowNth, dowLast           uint64
+	year                                  yearSet
+	and                                   bool // whether both day fields must match
+	gap                                   GapPolicy
+	repeat                                RepeatPolicy
+	location                              string
+}
+
+// normalized returns the normalized form of the schedule.
+func (s *SpecSchedule) normalized() normalizedSpec {
+	n := normalizedSpec{
+		second:         s.Second &^ starBit,
+		minute:         s.Minute &^ starBit,
+		hour:           s.Hour &^ starBit,
+		dom:            s.Dom &^ starBit,
+		month:          s.Month &^ starBit,
+		dow:            s.Dow &^ starBit,
+		domLast:        s.DomLast,
+		domLastWeekday: s.DomLastWeekday,
+		domWeekday:     s.DomWeekday,
+		dowNth:         s.DowNth,
+		dowLast:        s.DowLast,
+		year:           s.Year,
+		and:            s.daysAnd(),
+		gap:            s.Gap,
+		repeat:         s.Repeat,
+	}
+	if s.Location != nil {
+		n.location = s.Location.String()
+	}
+
+	// Either a day-of-month or a day-of-week that matches every day makes
+	// the other irrelevant if only one of them needs to match.
+	allDom, allDow := getBits(dom.min, dom.max, 1), getBits(dow.min, dow.max, 1)
+	if !n.and && (n.dom == allDom || n.dow == allDow) {
+		n.and = true
+		n.dom, n.dow = allDom, allDow
+		n.domLast, n.domLastWeekday = false, false
+		n.domWeekday, n.dowNth, n.dowLast = 0, 0, 0
+	}
+
+	// If both must match, a field that matches every day has no special
+	// values to speak of.
+	if n.and && n.dom == allDom {
+		n.domLast, n.domLastWeekday, n.domWeekday = false, false, 0
+	}
+	if n.and && n.dow == allDow {
+		n.dowNth, n.dowLast = 0, 0
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	equal := [][2]string{
+		{"0 0 * * *", "@daily"},
+		{"0 0 0 * * *", "@daily"},
+		{"0 0 0 ? * *", "@midnight"},
+		{"*/15 * * * *", "0 0,15,30,45 * * * *"},
+		{"0 0 * * 0-6", "0 0 * * *"},
+		{"0 0 1-31 * MON", "0 0 * * *"},
+		{"0 0 * * MON-FRI", "0 0 ? * 1-5"},
+		{"0 0 * * SUN", "0 0 * * 7"},
+		{"TZ=UTC 0 0 * * *", "CRON_TZ=UTC @daily"},
+		{"0 0 0 1-31,L * ?", "@daily"},
+		{"@every 1h", "@every 60m"},
+		{"@every 1h offset 15m", "@every 1h offset 75m"},
+	}
+	for _, c := range equal {
+		a, b := MustParse(c[0]), MustParse(c[1])
+		if !Equal(a, b) || !Equal(b, a) {
+			t.Errorf("expected %s and %s to be equal", c[0], c[1])
+		}
+		if Hash(a) != Hash(b) {
+			t.Errorf("expected %s and %s to have the same hash", c[0], c[1])
+		}
+	}
+
+	different := [][2]string{
+		{"0 0 * * *", "0 0 0 * * * 2027"},
+		{"0 0 13 * 5", "0 0 13 * *"},
+		{"0 0 13 * 5", "0 0 * * 5"},
+		{"0 0 1 * *", "0 0 L * *"},
+		{"TZ=UTC 0 0 * * *", "0 0 * * *"},
+		{"@every 1h", "@hourly"},
+		{"@every 1h", "@every 1h offset 0s"},
+		{"@every 1h offset 15m", "@every 1h offset 30m"},
+	}
+	for _, c := range different {
+		a, b := MustParse(c[0]), MustParse(c[1])
+		if Equal(a, b) || Equal(b, a) {
+			t.Errorf("expected %s and %s to differ", c[0], c[1])
+		}
+	}
+
+	u := Union(MustParse("@daily"), Every(time.Hour))
+	if !Equal(u, Union(MustParse("@daily"), Every(time.Hour))) || Hash(u) != Hash(Union(MustParse("@daily"), Every(time.Hour))) {
+		t.Error("expected identical unions to be equal")
+	}
+
+	at := time.Date(2012, 7, 9, 14, 45, 0, 0, time.UTC)
+	if a, b := Once(at), Once(at.In(time.Local)); !Equal(a, b) || Hash(a) != Hash(b) {
+		t.Error("expected schedules activating once at the same instant to be equal")
+	}
+	if Equal(Once(at), Once(at.Add(time.Second))) {
+		t.Error("expected schedules activating once at different instants to differ")
+	}
+}
+
+func TestEqualDomDowPolicy(t *testing.T) {
+	and := NewParser(WithDomDowPolicy(DomAndDow))
+	tests := []struct {
+		spec     string
+		expected bool
+	}{
+		{"0 0 13 * FRI", false},
+		{"0 0 * * FRI", true},
+		{"0 0 13 * *", true},
+	}
+	for _, c := range tests {
+		a := MustParse(c.spec)
+		b, err := and.Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := Equal(a, b); actual != c.expected {
+			t.Errorf("%s: Equal = %v, expected %v", c.spec, actual, c.expected)
+		}
+	}
+}
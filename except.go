@@ -0,0 +1,138 @@
+package cron
+
+import "time"
+
+// exclusionSearchLimit bounds the number of excluded activations that an
+// ExceptSchedule skips over before it gives up and returns the zero time.
+const exclusionSearchLimit = 100000
+
+// exclusion describes times at which a schedule must not activate.
+type exclusion interface {
+	// window returns whether t is excluded and, if so, the half-open window
+	// [start, end) of excluded times containing it.  A zero start or end
+	// means that the window is unbounded on that side.
+	window(t time.Time) (start, end time.Time, excluded bool)
+}
+
+// ExceptSchedule activates like a base schedule, except at excluded times.
+type ExceptSchedule struct {
+	base       Schedule
+	exclusions []exclusion
+}
+
+// Except returns a Schedule that activates like base, except within the
+// seconds at which any of the blackout schedules activate.  For example,
+// Except(every15min, MustParse("* * 2 * * *")) skips activations from 02:00 up
+// to 03:00.
+func Except(base Schedule, blackout ...Schedule) Schedule {
+	s := &ExceptSchedule{base: base}
+	for _, b := range blackout {
+		s.exclusions = append(s.exclusions, scheduleExclusion{b})
+	}
+	return s
+}
+
+// Blackout returns a Schedule that activates like base, except within the
+// windows that open at each activation of from and close at the following
+// activation of to.  Activations at the moment a window closes are kept.
+func Blackout(base, from, to Schedule) Schedule {
+	return &ExceptSchedule{base: base, exclusions: []exclusion{windowExclusion{from, to}}}
+}
+
+// ExceptBetween returns a Schedule that activates like base, except from start
+// up to (but not including) end.  A zero start or end leaves that side of the
+// range open.
+func ExceptBetween(base Schedule, start, end time.Time) Schedule {
+	return &ExceptSchedule{base: base, exclusions: []exclusion{rangeExclusion{start, end}}}
+}
+
+// Next returns the next activation of the base schedule that is not
+// excluded, or the zero time if there is none within a bounded search.
+func (schedule *ExceptSchedule) Next(t time.Time) time.Time {
+	for i := 0; i < exclusionSearchLimit; i++ {
+		t = schedule.base.Next(t)
+		if t.IsZero() {
+			return t
+		}
+		_, end, excluded := schedule.window(t)
+		if !excluded {
+			return t
+		}
+		if end.IsZero() {
+			return time.Time{}
+		}
+		t = end.Add(-time.Nanosecond)
+	}
+	return time.Time{}
+}
+
+// Prev returns the previous activation of the base schedule that was not
+// excluded, or the zero time if there is none within a bounded search.
+func (schedule *ExceptSchedule) Prev(t time.Time) time.Time {
+	for i := 0; i < exclusionSearchLimit; i++ {
+		t = previous(schedule.base, t)
+		if t.IsZero() {
+			return t
+		}
+		start, _, excluded := schedule.window(t)
+		if !excluded {
+			return t
+		}
+		if start.IsZero() {
+			return time.Time{}
+		}
+		t = start
+	}
+	return time.Time{}
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule *ExceptSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
+
+// window returns the first of the excluded windows containing t.
+func (schedule *ExceptSchedule) window(t time.Time) (start, end time.Time, excluded bool) {
+	for _, e := range schedule.exclusions {
+		if start, end, excluded = e.window(t); excluded {
+			return start, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// scheduleExclusion excludes each second in which a schedule activates.
+type scheduleExclusion struct {
+	schedule Schedule
+}
+
+func (e scheduleExclusion) window(t time.Time) (start, end time.Time, excluded bool) {
+	start = t.Truncate(time.Second)
+	end = start.Add(time.Second)
+	next := e.schedule.Next(start.Add(-time.Nanosecond))
+	return start, end, !next.IsZero() && next.Before(end)
+}
+
+// windowExclusion excludes the times from each activation of one schedule up
+// to the following activation of another.
+type windowExclusion struct {
+	from, to Schedule
+}
+
+func (e windowExclusion) window(t time.Time) (start, end time.Time, excluded bool) {
+	opened := previous(e.from, t.Add(time.Nanosecond))
+	closed := previous(e.to, t.Add(time.Nanosecond))
+	if opened.IsZero() || !opened.After(closed) {
+		return time.Time{}, time.Time{}, false
+	}
+	return opened, e.to.Next(t), true
+}
+
+// rangeExclusion excludes the times from start up to end.
+type rangeExclusion struct {
+	start, end time.Time
+}
+
+func (e rangeExclusion) window(t time.Time) (start, end time.Time, excluded bool) {
+	return e.start, e.end, !t.Before(e.start) && (e.end.IsZero() || t.Before(e.end))
+}
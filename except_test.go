@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExceptNext(t *testing.T) {
+	every15min := MustParse("0 */15 * * * *")
+	maintenance := Except(every15min, MustParse("* * 2 * * *"))
+	window := Blackout(every15min, MustParse("0 0 2 * * *"), MustParse("0 0 3 * * *"))
+	between := ExceptBetween(every15min, getTime("Mon Jul 9 02:00 2012"), getTime("Mon Jul 9 03:00 2012"))
+
+	tests := []struct {
+		schedule Schedule
+		time     string
+		expected string
+	}{
+		{maintenance, "Mon Jul 9 01:40 2012", "Mon Jul 9 01:45 2012"},
+		{maintenance, "Mon Jul 9 01:45 2012", "Mon Jul 9 03:00 2012"},
+		{maintenance, "Mon Jul 9 02:20 2012", "Mon Jul 9 03:00 2012"},
+
+		{window, "Mon Jul 9 01:45 2012", "Mon Jul 9 03:00 2012"},
+		{window, "Mon Jul 9 02:20 2012", "Mon Jul 9 03:00 2012"},
+		{window, "Mon Jul 9 03:00 2012", "Mon Jul 9 03:15 2012"},
+		{window, "Tue Jul 10 01:50 2012", "Tue Jul 10 03:00 2012"},
+
+		// Time ranges are half-open, and do not recur.
+		{between, "Mon Jul 9 01:45 2012", "Mon Jul 9 03:00 2012"},
+		{between, "Tue Jul 10 01:50 2012", "Tue Jul 10 02:00 2012"},
+
+		// Exclusions match the whole second.
+		{Except(MustParse("* * * * * *"), MustParse("1 * * * * *")), "Mon Jul 9 01:00:00 2012", "Mon Jul 9 01:00:02 2012"},
+		{Except(MustParse("* * * * * *"), EveryAligned(time.Minute, time.Second)), "Mon Jul 9 01:00:00 2012", "Mon Jul 9 01:00:02 2012"},
+
+		// Several exclusions.
+		{Except(every15min, MustParse("* * 2 * * *"), MustParse("* * 3 * * *")), "Mon Jul 9 01:45 2012", "Mon Jul 9 04:00 2012"},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.Next(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestExceptPrev(t *testing.T) {
+	every15min := MustParse("0 */15 * * * *")
+	tests := []struct {
+		schedule Schedule
+		time     string
+		expected string
+	}{
+		{Except(every15min, MustParse("* * 2 * * *")), "Mon Jul 9 03:10 2012", "Mon Jul 9 03:00 2012"},
+		{Except(every15min, MustParse("* * 2 * * *")), "Mon Jul 9 03:00 2012", "Mon Jul 9 01:45 2012"},
+		{Blackout(every15min, MustParse("0 0 2 * * *"), MustParse("0 0 3 * * *")), "Mon Jul 9 03:00 2012", "Mon Jul 9 01:45 2012"},
+		{ExceptBetween(every15min, getTime("Mon Jul 9 02:00 2012"), getTime("Mon Jul 9 03:00 2012")), "Mon Jul 9 02:50 2012", "Mon Jul 9 01:45 2012"},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.(PrevSchedule).Prev(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestExceptEverything(t *testing.T) {
+	from := getTime("Mon Jul 9 01:45 2012")
+	schedules := []Schedule{
+		Except(MustParse("0 */15 * * * *"), MustParse("* * * * * *")),
+		Blackout(MustParse("0 */15 * * * *"), MustParse("0 0 0 * * *"), MustParse("0 0 0 1 1 * 2011")),
+		ExceptBetween(MustParse("0 */15 * * * *"), from, time.Time{}),
+	}
+	for i, s := range schedules {
+		if next := s.Next(from); !next.IsZero() {
+			t.Errorf("%d: expected the zero time, got %v", i, next)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package cron
+
+import (
+	"math/bits"
+	"time"
+)
+
+// Field identifies one of the fields of a SpecSchedule.
+type Field int
+
+// The fields of a SpecSchedule, in the order they appear in a spec.
+const (
+	SecondField Field = iota
+	MinuteField
+	HourField
+	DomField
+	MonthField
+	DowField
+	YearField
+)
+
+// String returns the name of the field, e.g. "day-of-month".
+func (f Field) String() string {
+	if f < SecondField || f > YearField {
+		return "unknown"
+	}
+	return fieldBounds[f].field
+}
+
+// Seconds returns the seconds at which the schedule activates, in order.
+func (s *SpecSchedule) Seconds() []int {
+	return bitValues(s.Second)
+}
+
+// Minutes returns the minutes at which the schedule activates, in order.
+func (s *SpecSchedule) Minutes() []int {
+	return bitValues(s.Minute)
+}
+
+// Hours returns the hours at which the schedule activates, in order.
+func (s *SpecSchedule) Hours() []int {
+	return bitValues(s.Hour)
+}
+
+// DaysOfMonth returns the days of the month on which the schedule activates,
+// in order.  The special "L" and "W" days are not included.
+func (s *SpecSchedule) DaysOfMonth() []int {
+	return bitValues(s.Dom)
+}
+
+// Months returns the months in which the schedule activates, in order.
+func (s *SpecSchedule) Months() []time.Month {
+	var months []time.Month
+	for _, m := range bitValues(s.Month) {
+		months = append(months, time.Month(m))
+	}
+	return months
+}
+
+// DaysOfWeek returns the days of the week on which the schedule activates, in
+// order from Sunday.  The special "#" and "L" days are not included.
+func (s *SpecSchedule) DaysOfWeek() []time.Weekday {
+	var days []time.Weekday
+	for _, d := range bitValues(s.Dow) {
+		days = append(days, time.Weekday(d))
+	}
+	return days
+}
+
+// Years returns the years in which the schedule activates, in order, or nil if
+// the year is not restricted.
+func (s *SpecSchedule) Years() []int {
+	var values []int
+	for i, word := range s.Year {
+		for ; word != 0; word &= word - 1 {
+			values = append(values, int(years.min)+i*64+bits.TrailingZeros64(word))
+		}
+	}
+	return values
+}
+
+// IsWildcard returns true if the given field was specified with a star (or a
+// question mark), e.g. "*" or "*/5", rather than with particular values.  The
+// year field is a wildcard if the year is not restricted.
+func (s *SpecSchedule) IsWildcard(f Field) bool {
+	switch f {
+	case SecondField:
+		return s.Second&starBit > 0
+	case MinuteField:
+		return s.Minute&starBit > 0
+	case HourField:
+		return s.Hour&starBit > 0
+	case DomField:
+		return s.Dom&starBit > 0
+	case MonthField:
+		return s.Month&starBit > 0
+	case DowField:
+		return s.Dow&starBit > 0
+	case YearField:
+		return s.Year.isZero()
+	}
+	return false
+}
+
+// bitValues returns the positions of the bits set in b, in order, ignoring the
+// star bit.
+func bitValues(b uint64) []int {
+	var values []int
+	for b &^= starBit; b != 0; b &= b - 1 {
+		values = append(values, bits.TrailingZeros64(b))
+	}
+	return values
+}
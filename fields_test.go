@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFieldValues(t *testing.T) {
+	s := MustParse("0,30 */15 9-17 1,15,L JAN,JUL MON-FRI 2027,2030").(*SpecSchedule)
+
+	if actual, expected := s.Seconds(), []int{0, 30}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("seconds: (expected) %v != %v (actual)", expected, actual)
+	}
+	if actual, expected := s.Minutes(), []int{0, 15, 30, 45}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("minutes: (expected) %v != %v (actual)", expected, actual)
+	}
+	if actual, expected := s.Hours(), []int{9, 10, 11, 12, 13, 14, 15, 16, 17}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("hours: (expected) %v != %v (actual)", expected, actual)
+	}
+	if actual, expected := s.DaysOfMonth(), []int{1, 15}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("days of month: (expected) %v != %v (actual)", expected, actual)
+	}
+	if actual, expected := s.Months(), []time.Month{time.January, time.July}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("months: (expected) %v != %v (actual)", expected, actual)
+	}
+	expectedDays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	if actual := s.DaysOfWeek(); !reflect.DeepEqual(actual, expectedDays) {
+		t.Errorf("days of week: (expected) %v != %v (actual)", expectedDays, actual)
+	}
+	if actual, expected := s.Years(), []int{2027, 2030}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("years: (expected) %v != %v (actual)", expected, actual)
+	}
+
+	all := MustParse("* * * * * * 2099").(*SpecSchedule)
+	if n := len(all.Seconds()); n != 60 {
+		t.Errorf("expected 60 seconds, got %d", n)
+	}
+	if actual := all.DaysOfWeek(); len(actual) != 7 || actual[0] != time.Sunday {
+		t.Errorf("unexpected days of week: %v", actual)
+	}
+	if actual := all.Years(); !reflect.DeepEqual(actual, []int{2099}) {
+		t.Errorf("unexpected years: %v", actual)
+	}
+	if actual := MustParse("0 0 0 1 1 * 2033").(*SpecSchedule).Years(); !reflect.DeepEqual(actual, []int{2033}) {
+		t.Errorf("unexpected years: %v", actual)
+	}
+	if actual := MustParse("@daily").(*SpecSchedule).Years(); actual != nil {
+		t.Errorf("expected no years, got %v", actual)
+	}
+}
+
+func TestIsWildcard(t *testing.T) {
+	s := MustParse("0 */15 9-17 ? * MON-FRI").(*SpecSchedule)
+	expected := map[Field]bool{
+		SecondField: false,
+		MinuteField: true,
+		HourField:   false,
+		DomField:    true,
+		MonthField:  true,
+		DowField:    false,
+		YearField:   true,
+	}
+	for f, wildcard := range expected {
+		if actual := s.IsWildcard(f); actual != wildcard {
+			t.Errorf("%s: (expected) %v != %v (actual)", f, wildcard, actual)
+		}
+	}
+	if MustParse("0 0 0 1 1 ? 2027").(*SpecSchedule).IsWildcard(YearField) {
+		t.Error("expected a restricted year not to be a wildcard")
+	}
+	if DomField.String() != "day-of-month" || Field(42).String() != "unknown" {
+		t.Error("unexpected field names")
+	}
+}
@@ -0,0 +1,197 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// String returns a canonical spec for the schedule, which Parse turns back
+// into an equivalent schedule.  It has 6 fields, or 7 if the year is
+// restricted, and is prefixed by the location unless that is time.Local, then
+// by "DOMDOW=AND", "GAP=SKIP" and "REPEAT=BOTH" if the schedule has those
+// policies.
+func (s *SpecSchedule) String() string {
+	fields := []string{
+		formatField(s.Second, seconds, nil),
+		formatField(s.Minute, minutes, nil),
+		formatField(s.Hour, hours, nil),
+		formatField(s.Dom, dom, s.domExtras()),
+		formatField(s.Month, months, nil),
+		formatField(s.Dow, dow, s.dowExtras()),
+	}
+	if !s.Year.isZero() {
+		fields = append(fields, formatList(s.Years()))
+	}
+	var prefixes []string
+	if s.Location != nil && s.Location != time.Local {
+		prefixes = append(prefixes, "TZ="+s.Location.String())
+	}
+	if s.DomDow == DomAndDow {
+		prefixes = append(prefixes, "DOMDOW=AND")
+	}
+	if s.Gap == SkipGap {
+		prefixes = append(prefixes, "GAP=SKIP")
+	}
+	if s.Repeat == RunBothRepeats {
+		prefixes = append(prefixes, "REPEAT=BOTH")
+	}
+	return strings.Join(append(prefixes, fields...), " ")
+}
+
+// To5Field returns the canonical classic 5-field form of the given spec, which
+// may be in any form that Parse accepts, including a descriptor such as
+// "@daily".  It returns an error if the spec is not valid, or if it cannot be
+// written in 5 fields: if it activates at seconds other than 0, in particular
+// years only, or at intervals, as "@every" does.  Time zone and policy prefixes
+// are kept as written.
+func To5Field(spec string) (string, error) {
+	prefix, fields, err := canonicalFields(Parse, spec)
+	if err != nil {
+		return "", err
+	}
+	if fields[0] != "0" {
+		return "", fmt.Errorf("seconds field %q is not representable in 5 fields: %q", fields[0], spec)
+	}
+	if len(fields) > 6 {
+		return "", fmt.Errorf("year field %q is not representable in 5 fields: %q", fields[6], spec)
+	}
+	return prefix + strings.Join(fields[1:], " "), nil
+}
+
+// To6Field returns the canonical 6-field form of the given standard 5-field
+// spec or descriptor, which activates at second 0.  It returns an error if the
+// spec is not accepted by ParseStandard, or if it activates at intervals, as
+// "@every" does.  Time zone and policy prefixes are kept as written.
+func To6Field(spec string) (string, error) {
+	prefix, fields, err := canonicalFields(ParseStandard, spec)
+	if err != nil {
+		return "", err
+	}
+	return prefix + strings.Join(fields, " "), nil
+}
+
+// canonicalFields parses the spec with parse, and returns the time zone and
+// policy prefixes of the spec as written, followed by a space if there are any,
+// and the fields of the canonical spec of the resulting schedule.
+func canonicalFields(parse func(string) (Schedule, error), spec string) (prefix string, fields []string, err error) {
+	sched, err := parse(spec)
+	if err != nil {
+		return "", nil, err
+	}
+	s, ok := sched.(*SpecSchedule)
+	if !ok {
+		return "", nil, fmt.Errorf("schedule is not representable in fields: %q", spec)
+	}
+	spec = strings.TrimSpace(spec)
+	if _, _, rest, _ := NewParser().parsePrefixes(spec); rest != spec {
+		prefix = strings.TrimSpace(spec[:len(spec)-len(rest)]) + " "
+	}
+	plain := *s
+	plain.Location, plain.DomDow, plain.Gap, plain.Repeat = time.Local, DomOrDow, RunAfterGap, RunFirstRepeat
+	return prefix, strings.Fields(plain.String()), nil
+}
+
+// domExtras returns the special day-of-month expressions of the schedule.
+func (s *SpecSchedule) domExtras() []string {
+	var extras []string
+	for _, day := range bitValues(s.DomWeekday) {
+		extras = append(extras, strconv.Itoa(day)+"W")
+	}
+	if s.DomLast {
+		extras = append(extras, "L")
+	}
+	if s.DomLastWeekday {
+		extras = append(extras, "LW")
+	}
+	return extras
+}
+
+// dowExtras returns the special day-of-week expressions of the schedule.
+func (s *SpecSchedule) dowExtras() []string {
+	var extras []string
+	for _, bit := range bitValues(s.DowNth) {
+		extras = append(extras, strconv.Itoa(bit%7)+"#"+strconv.Itoa(bit/7+1))
+	}
+	for _, day := range bitValues(s.DowLast) {
+		extras = append(extras, strconv.Itoa(day)+"L")
+	}
+	return extras
+}
+
+// formatField returns the expression for a field with the given bits, followed
+// by any extra expressions.  If the star bit is set, the expression begins
+// with a star, stepped if need be.
+func formatField(b uint64, r bounds, extras []string) string {
+	values := bitValues(b)
+	var list []string
+	if b&starBit > 0 {
+		if star, rest, ok := formatStar(values, r); ok {
+			list = append(list, star)
+			values = rest
+		}
+	}
+	if len(values) > 0 {
+		list = append(list, formatList(values))
+	}
+	return strings.Join(append(list, extras...), ",")
+}
+
+// formatStar returns the starred expression "*" or "*/step" that covers the
+// most of the given values, along with the values it does not cover.
+func formatStar(values []int, r bounds) (star string, rest []int, ok bool) {
+	set := make(map[int]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	for step := 1; step <= int(r.max-r.min)+1; step++ {
+		covered := true
+		for v := int(r.min); v <= int(r.max); v += step {
+			if !set[v] {
+				covered = false
+				break
+			}
+		}
+		if !covered {
+			continue
+		}
+		for _, v := range values {
+			if (v-int(r.min))%step != 0 {
+				rest = append(rest, v)
+			}
+		}
+		if step == 1 {
+			return "*", rest, true
+		}
+		return "*/" + strconv.Itoa(step), rest, true
+	}
+	return "", nil, false
+}
+
+// formatList returns a comma-separated list of the given ordered values, with
+// runs of three or more evenly spaced values written as ranges.
+func formatList(values []int) string {
+	var list []string
+	for i := 0; i < len(values); {
+		j := i + 1
+		if j < len(values) {
+			step := values[j] - values[i]
+			for j+1 < len(values) && values[j+1]-values[j] == step {
+				j++
+			}
+			if j-i >= 2 {
+				expr := strconv.Itoa(values[i]) + "-" + strconv.Itoa(values[j])
+				if step > 1 {
+					expr += "/" + strconv.Itoa(step)
+				}
+				list = append(list, expr)
+				i = j + 1
+				continue
+			}
+		}
+		list = append(list, strconv.Itoa(values[i]))
+		i++
+	}
+	return strings.Join(list, ",")
+}
@@ -0,0 +1,176 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpecString(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"* * * * * *", "* * * * * *"},
+		{"0 0 * * *", "0 0 0 * * *"},
+		{"@daily", "0 0 0 * * *"},
+		{"@weekly", "0 0 0 * * 0"},
+		{"@weekday", "0 0 0 * * 1-5"},
+		{"*/15 * * * *", "0 */15 * * * *"},
+		{"0 0/15 * * * *", "0 0-45/15 * * * *"},
+		{"5/15 * * * *", "0 5-50/15 * * * *"},
+		{"0 1,2,3,5,7 * * *", "0 0 1-3,5,7 * * *"},
+		{"0 9 * JAN,JUL MON-FRI", "0 0 9 * 1,7 1-5"},
+		{"0 0 0 1,15,L * ?", "0 0 0 1,15,L * *"},
+		{"0 0 0 15W,LW * ?", "0 0 0 15W,LW * *"},
+		{"0 0 0 ? * MON#2,FRIL", "0 0 0 * * 1#2,5L"},
+		{"0 0 12 1 1 ? 2027,2029-2031", "0 0 12 1 1 * 2027,2029-2031"},
+		{"TZ=UTC 0 0 * * *", "TZ=UTC 0 0 0 * * *"},
+		{"CRON_TZ=Asia/Tokyo 0 0 * * *", "TZ=Asia/Tokyo 0 0 0 * * *"},
+		{"0 22-2 * * *", "0 0 0-2,22,23 * * *"},
+		{"DOMDOW=AND 0 0 13 * FRI", "DOMDOW=AND 0 0 0 13 * 5"},
+		{"DOMDOW=and TZ=UTC 0 0 13 * FRI", "TZ=UTC DOMDOW=AND 0 0 0 13 * 5"},
+		{"DOMDOW=OR 0 0 13 * FRI", "0 0 0 13 * 5"},
+		{"REPEAT=BOTH GAP=SKIP DOMDOW=AND TZ=UTC 30 1 13 * FRI", "TZ=UTC DOMDOW=AND GAP=SKIP REPEAT=BOTH 0 30 1 13 * 5"},
+	}
+	for _, c := range tests {
+		actual := MustParse(c.spec).(*SpecSchedule).String()
+		if actual != c.expected {
+			t.Errorf("%s => (expected) %s != %s (actual)", c.spec, c.expected, actual)
+		}
+	}
+}
+
+func TestSpecStringRoundTrip(t *testing.T) {
+	corpus := []string{
+		"* * * * * *",
+		"*/7 * * * * *",
+		"0 */10,5 * * * *",
+		"1,3,4,8,9,10 * * * * *",
+		"0 0 4-20/4 * * *",
+		"0 0 0 */2 * *",
+		"0 0 0 */2 * MON",
+		"0 0 0 1,15 * MON",
+		"0 0 0 * * */2",
+		"0 0 0 */3,2 * *",
+		"0 0 0 L * *",
+		"0 0 0 1W * MON",
+		"0 0 0 LW,L,3 * *",
+		"0 0 0 ? * 0#1,6#5,3L",
+		"0 0 0 ? * 7",
+		"0 0 0 * JUN-AUG *",
+		"0 0 0 * NOV-FEB */2",
+		"0 0 0 1 1 * 1970-2099/7",
+		"0 0 0 1 1 * 2099",
+		"TZ=America/New_York 30 2 * * *",
+		"DOMDOW=AND 0 0 9 1-7 * MON",
+		"TZ=UTC DOMDOW=AND 0 0 0 13 * FRI",
+		"GAP=SKIP TZ=America/New_York 0 30 2 * * *",
+		"REPEAT=BOTH TZ=America/New_York 0 30 1 * * *",
+		"@yearly",
+		"@monthly",
+		"@hourly",
+		"@minutely",
+		"@weekend",
+	}
+	for _, spec := range corpus {
+		expected := MustParse(spec)
+		str := expected.(*SpecSchedule).String()
+		actual, err := Parse(str)
+		if err != nil {
+			t.Errorf("%s => %s: %v", spec, str, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s => %s: (expected) %v != %v (actual)", spec, str, expected, actual)
+		}
+	}
+}
+
+func TestTo5Field(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"0 30 9 * * MON-FRI", "30 9 * * 1-5"},
+		{"30 9 * * MON-FRI", "30 9 * * 1-5"},
+		{"0 */15 * * * *", "*/15 * * * *"},
+		{"@daily", "0 0 * * *"},
+		{"@weekly", "0 0 * * 0"},
+		{"@hourly", "0 * * * *"},
+		{"@weekday", "0 0 * * 1-5"},
+		{"0 0 0 L * *", "0 0 L * *"},
+		{"TZ=UTC 0 0 12 * * *", "TZ=UTC 0 12 * * *"},
+		{`TZ="Asia/Tokyo" @daily`, `TZ="Asia/Tokyo" 0 0 * * *`},
+		{"  CRON_TZ=UTC   0 5 * * *", "CRON_TZ=UTC 0 5 * * *"},
+		{"DOMDOW=AND TZ=UTC 0 0 0 13 * FRI", "DOMDOW=AND TZ=UTC 0 0 13 * 5"},
+	}
+	for _, c := range tests {
+		actual, err := To5Field(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s => (expected) %s != %s (actual)", c.spec, c.expected, actual)
+		}
+		if !reflect.DeepEqual(MustParse(actual), MustParse(c.spec)) {
+			t.Errorf("%s => %s parses to a different schedule", c.spec, actual)
+		}
+	}
+
+	invalid := []struct {
+		spec, expected string
+	}{
+		{"30 0 9 * * *", `seconds field "30" is not representable in 5 fields: "30 0 9 * * *"`},
+		{"* * * * * *", `seconds field "*" is not representable in 5 fields: "* * * * * *"`},
+		{"0 0 0 1 1 * 2030", `year field "2030" is not representable in 5 fields: "0 0 0 1 1 * 2030"`},
+		{"@every 1h", `schedule is not representable in fields: "@every 1h"`},
+		{"@reboot", `schedule is not representable in fields: "@reboot"`},
+		{"* * * *", `expected 5 to 7 fields, found 4: "* * * *"`},
+	}
+	for _, c := range invalid {
+		_, err := To5Field(c.spec)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("%s => expected %q, got %v", c.spec, c.expected, err)
+		}
+	}
+}
+
+func TestTo6Field(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"30 9 * * MON-FRI", "0 30 9 * * 1-5"},
+		{"*/15 * * * *", "0 */15 * * * *"},
+		{"@daily", "0 0 0 * * *"},
+		{"@monthly", "0 0 0 1 * *"},
+		{"0 0 L * 5L", "0 0 0 L * 5L"},
+		{"TZ=Asia/Tokyo 0 9 * * *", "TZ=Asia/Tokyo 0 0 9 * * *"},
+		{"CRON_TZ='UTC' @hourly", "CRON_TZ='UTC' 0 0 * * * *"},
+	}
+	for _, c := range tests {
+		actual, err := To6Field(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s => (expected) %s != %s (actual)", c.spec, c.expected, actual)
+		}
+		if !reflect.DeepEqual(MustParse(actual), MustParse(c.spec)) {
+			t.Errorf("%s => %s parses to a different schedule", c.spec, actual)
+		}
+		back, err := To5Field(actual)
+		if err != nil {
+			t.Errorf("%s => unexpected error converting back: %v", actual, err)
+			continue
+		}
+		if !reflect.DeepEqual(MustParse(back), MustParse(c.spec)) {
+			t.Errorf("%s => %s does not round-trip", c.spec, back)
+		}
+	}
+
+	for _, spec := range []string{"0 30 9 * * *", "@every 5m", "0 99 * * *"} {
+		if _, err := To6Field(spec); err == nil {
+			t.Errorf("%s => expected an error", spec)
+		}
+	}
+}
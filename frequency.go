@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"math/bits"
+	"time"
+)
+
+// ActivationsPerDay returns the average number of times the schedule activates
+// in a day, rounded up, so that it is 0 only for a schedule that never
+// activates.  It is computed from the fields of the schedule rather than by
+// searching through its activations.
+//
+// The number of activations on each day the schedule activates is exact, but
+// when the days are restricted, e.g. by day-of-month or day-of-week, the
+// average spreads them over every day of the 400-year Gregorian calendar cycle,
+// or of the years the schedule is restricted to.  Daylight savings transitions
+// are not taken into account.
+func ActivationsPerDay(s *SpecSchedule) int {
+	perDay := bits.OnesCount64(s.Second&^starBit) *
+		bits.OnesCount64(s.Minute&^starBit) *
+		bits.OnesCount64(s.Hour&^starBit)
+
+	from, to := 2000, 2399
+	if !s.Year.isZero() {
+		from, to = int(years.min), int(years.max)
+	}
+	var active, total int
+	for year := from; year <= to; year++ {
+		if !s.Year.contains(year) {
+			continue
+		}
+		for month := time.January; month <= time.December; month++ {
+			total += daysInMonth(year, month)
+			if 1<<uint(month)&s.Month > 0 {
+				active += bits.OnesCount64(s.dayMask(year, month))
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return (perDay*active + total - 1) / total
+}
+
+// MinInterval returns the smallest gap between consecutive activations of the
+// schedule that are after from and not after from plus horizon, or 0 if it
+// activates fewer than twice in that time.  It estimates the smallest gap
+// overall, which may fall outside of the horizon: the gaps of "0 0 9 1,2 * *"
+// are a day long only from the 1st of each month to the 2nd.
+func MinInterval(s Schedule, from time.Time, horizon time.Duration) time.Duration {
+	var (
+		shortest time.Duration
+		prev     time.Time
+	)
+	for t := range Between(s, from, from.Add(horizon)) {
+		if !prev.IsZero() {
+			if gap := t.Sub(prev); shortest == 0 || gap < shortest {
+				shortest = gap
+			}
+		}
+		prev = t
+	}
+	return shortest
+}
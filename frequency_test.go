@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivationsPerDay(t *testing.T) {
+	entries := []struct {
+		spec     string
+		expected int
+	}{
+		{"* * * * * *", 86400},
+		{"0 * * * * *", 1440},
+		{"0 0 * * * *", 24},
+		{"@daily", 1},
+		{"0 */15 9-17 * * *", 36},
+		{"*/10 * * * * *", 8640},
+		{"*/10 * * * * MON-FRI", 6172},
+		{"0 0 9 * * MON-FRI", 1},
+		{"0 0 9 1 1 *", 1},
+		{"0 0 0 29 2 *", 1},
+		{"0 0 0 30 2 *", 0},
+		{"0 0 0 * * * 2030", 1},
+		{"0 0 0 * * * 1971", 1},
+		{"0 * * * * * 2020-2021", 1440},
+		{"0 * * * FEB * 2021", 111},
+	}
+	for _, c := range entries {
+		actual := ActivationsPerDay(MustParse(c.spec).(*SpecSchedule))
+		if actual != c.expected {
+			t.Errorf("%s => %d, expected %d", c.spec, actual, c.expected)
+		}
+	}
+}
+
+func TestMinInterval(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries := []struct {
+		schedule Schedule
+		horizon  time.Duration
+		expected time.Duration
+	}{
+		{MustParse("TZ=UTC * * * * * *"), time.Hour, time.Second},
+		{MustParse("TZ=UTC 0 0,30 9 * * *"), 48 * time.Hour, 30 * time.Minute},
+		{MustParse("TZ=UTC 0 0 9,17 * * *"), 48 * time.Hour, 8 * time.Hour},
+		{MustParse("TZ=UTC 0 0 9 1,2 * *"), 48 * time.Hour, 24 * time.Hour},
+		{MustParse("TZ=UTC 0 0 9 1,3 * *"), 24 * 40 * time.Hour, 48 * time.Hour},
+		{MustParse("TZ=UTC 0 0 9 * * *"), 12 * time.Hour, 0},
+		{MustParse("TZ=UTC 0 0 0 30 2 *"), 24 * 365 * time.Hour, 0},
+		{Union(MustParse("TZ=UTC 0 0 9 * * *"), MustParse("TZ=UTC 0 5 9 * * *")), 48 * time.Hour, 5 * time.Minute},
+		{EveryAligned(90*time.Minute, 0), 24 * time.Hour, 90 * time.Minute},
+	}
+	for i, c := range entries {
+		if actual := MinInterval(c.schedule, from, c.horizon); actual != c.expected {
+			t.Errorf("%d => %v, expected %v", i, actual, c.expected)
+		}
+	}
+}
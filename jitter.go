@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// JitterSchedule delays each activation of a schedule by a random amount, so
+// that many copies of the same schedule do not all activate at once.
+type JitterSchedule struct {
+	Schedule Schedule
+	Max      time.Duration // activations are delayed by up to (but not including) Max
+
+	mu   sync.Mutex
+	rand *rand.Rand // nil to use the default source
+}
+
+// WithJitter returns a Schedule that activates like s, but delayed by a random
+// amount in [0, max) that is drawn anew for each activation.  An activation is
+// never delayed past the one that follows it.
+func WithJitter(s Schedule, max time.Duration) Schedule {
+	return &JitterSchedule{Schedule: s, Max: max}
+}
+
+// WithJitterSource is like WithJitter, but draws the delays from src, e.g. to
+// make them deterministic in tests.
+func WithJitterSource(s Schedule, max time.Duration, src rand.Source) Schedule {
+	return &JitterSchedule{Schedule: s, Max: max, rand: rand.New(src)}
+}
+
+// Next returns the next activation of the wrapped schedule, plus the jitter.
+func (schedule *JitterSchedule) Next(t time.Time) time.Time {
+	next := schedule.Schedule.Next(t)
+	if next.IsZero() {
+		return next
+	}
+	limit := schedule.Max
+	if following := schedule.Schedule.Next(next); !following.IsZero() && following.Sub(next) < limit {
+		limit = following.Sub(next)
+	}
+	if limit <= 0 {
+		return next
+	}
+	return next.Add(schedule.jitter(limit))
+}
+
+// Prev returns the previous activation of the wrapped schedule, without any
+// jitter, as the delay it was given is not remembered.
+func (schedule *JitterSchedule) Prev(t time.Time) time.Time {
+	return previous(schedule.Schedule, t)
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule *JitterSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
+
+// jitter returns a random duration in [0, limit).
+func (schedule *JitterSchedule) jitter(limit time.Duration) time.Duration {
+	if schedule.rand == nil {
+		return time.Duration(rand.Int63n(int64(limit)))
+	}
+	schedule.mu.Lock()
+	defer schedule.mu.Unlock()
+	return time.Duration(schedule.rand.Int63n(int64(limit)))
+}
@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestJitterNext(t *testing.T) {
+	hourly := MustParse("0 0 * * * *")
+	schedule := WithJitterSource(hourly, 5*time.Minute, rand.NewSource(1))
+
+	from := getTime("Mon Jul 9 14:45 2012")
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		next := schedule.Next(from)
+		delay := next.Sub(getTime("Mon Jul 9 15:00 2012"))
+		if delay < 0 || delay >= 5*time.Minute {
+			t.Fatalf("jitter %v outside of [0, 5m)", delay)
+		}
+		seen[delay] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected the jitter to be drawn anew for each activation")
+	}
+
+	// The same source produces the same delays.
+	a := WithJitterSource(hourly, 5*time.Minute, rand.NewSource(42))
+	b := WithJitterSource(hourly, 5*time.Minute, rand.NewSource(42))
+	for i := 0; i < 10; i++ {
+		if x, y := a.Next(from), b.Next(from); x != y {
+			t.Fatalf("(expected) %v != %v (actual)", x, y)
+		}
+	}
+}
+
+func TestJitterClamp(t *testing.T) {
+	schedule := WithJitterSource(MustParse("0 * * * * *"), time.Hour, rand.NewSource(1))
+	from := getTime("Mon Jul 9 14:45 2012")
+	for i := 0; i < 100; i++ {
+		next := schedule.Next(from)
+		if !next.After(from) || !next.Before(getTime("Mon Jul 9 14:47 2012")) {
+			t.Fatalf("%v not before the following activation", next)
+		}
+	}
+}
+
+func TestJitterEdges(t *testing.T) {
+	from := getTime("Mon Jul 9 14:45 2012")
+
+	// No jitter.
+	if next := WithJitter(MustParse("0 0 * * * *"), 0).Next(from); next != getTime("Mon Jul 9 15:00 2012") {
+		t.Errorf("unexpected activation without jitter: %v", next)
+	}
+
+	// Schedules that stop activating.
+	if next := WithJitter(MustParse("0 0 0 1 1 * 2011"), time.Minute).Next(from); !next.IsZero() {
+		t.Errorf("expected the zero time, got %v", next)
+	}
+
+	// The last activation may be jittered by the full amount.
+	last := getTime("Mon Jul 9 15:00 2012")
+	next := WithJitter(Until(MustParse("0 0 * * * *"), last), time.Minute).Next(from)
+	if next.Before(last) || !next.Before(last.Add(time.Minute)) {
+		t.Errorf("unexpected last activation: %v", next)
+	}
+
+	// Prev is not jittered.
+	if prev := WithJitter(MustParse("0 0 * * * *"), time.Minute).(PrevSchedule).Prev(from); prev != getTime("Mon Jul 9 14:00 2012") {
+		t.Errorf("unexpected previous activation: %v", prev)
+	}
+}
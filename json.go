@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes the schedule as its canonical spec string.
+func (s *SpecSchedule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a spec string, such as one produced by MarshalJSON,
+// using Parse.  The spec must describe a SpecSchedule rather than, say, an
+// "@every" interval.
+func (s *SpecSchedule) UnmarshalJSON(data []byte) error {
+	schedule, err := unmarshalSpec(data)
+	if err != nil {
+		return err
+	}
+	spec, ok := schedule.(*SpecSchedule)
+	if !ok {
+		return fmt.Errorf("spec is not a crontab schedule: %s", data)
+	}
+	*s = *spec
+	return nil
+}
+
+// MarshalJSON encodes the schedule as its "@every" descriptor.
+func (schedule ConstantDelaySchedule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(schedule.String())
+}
+
+// UnmarshalJSON decodes an "@every" descriptor using Parse.
+func (schedule *ConstantDelaySchedule) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalSpec(data)
+	if err != nil {
+		return err
+	}
+	delay, ok := parsed.(ConstantDelaySchedule)
+	if !ok {
+		return fmt.Errorf("spec is not an @every schedule: %s", data)
+	}
+	*schedule = delay
+	return nil
+}
+
+// unmarshalSpec parses the JSON-encoded spec string.
+func unmarshalSpec(data []byte) (Schedule, error) {
+	var spec string
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	return Parse(spec)
+}
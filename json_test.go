@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSpecScheduleJSON(t *testing.T) {
+	for _, spec := range []string{
+		"@daily",
+		"@weekly",
+		"0 */5 * * *",
+		"30 0 9 * * MON-FRI",
+		"0 0 12 1 1 ? 2027",
+		"TZ=UTC 0 0 * * *",
+		"CRON_TZ=America/New_York 0 30 2 * * *",
+		"0 0 0 L * ?",
+		"DOMDOW=AND 0 0 13 * FRI",
+		"TZ=America/New_York GAP=SKIP 0 30 2 * * *",
+		"TZ=America/New_York REPEAT=BOTH GAP=SKIP DOMDOW=AND 0 30 1 13 * FRI",
+	} {
+		expected := MustParse(spec).(*SpecSchedule)
+		data, err := json.Marshal(expected)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		var actual SpecSchedule
+		if err := json.Unmarshal(data, &actual); err != nil {
+			t.Errorf("%s => %s: %v", spec, data, err)
+			continue
+		}
+		if !reflect.DeepEqual(&actual, expected) {
+			t.Errorf("%s => %s: (expected) %v != %v (actual)", spec, data, expected, &actual)
+		}
+	}
+
+	// Embedded in a struct.
+	var entry struct {
+		Name     string
+		Schedule *SpecSchedule
+	}
+	if err := json.Unmarshal([]byte(`{"Name": "nightly", "Schedule": "TZ=UTC @midnight"}`), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(entry.Schedule, midnight(time.UTC)) {
+		t.Errorf("unexpected schedule: %v", entry.Schedule)
+	}
+}
+
+func TestConstantDelayJSON(t *testing.T) {
+	expected := Every(90 * time.Minute)
+	data, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"@every 1h30m"` {
+		t.Errorf("unexpected encoding: %s", data)
+	}
+	var actual ConstantDelaySchedule
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if actual != expected {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+func TestScheduleJSONErrors(t *testing.T) {
+	var s SpecSchedule
+	var perr *ParseError
+	if err := json.Unmarshal([]byte(`"0 0 99 * *"`), &s); !errors.As(err, &perr) {
+		t.Errorf("expected a *ParseError, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`"@every 5m"`), &s); err == nil {
+		t.Error("expected an error decoding an interval into a SpecSchedule")
+	}
+	if err := json.Unmarshal([]byte(`42`), &s); err == nil {
+		t.Error("expected an error decoding a number")
+	}
+	var d ConstantDelaySchedule
+	if err := json.Unmarshal([]byte(`"@daily"`), &d); err == nil {
+		t.Error("expected an error decoding a crontab spec into a ConstantDelaySchedule")
+	}
+}
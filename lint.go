@@ -0,0 +1,141 @@
+package cron
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WarningCode identifies the kind of a Warning.
+type WarningCode string
+
+const (
+	// WarnEveryMinute is reported for a spec whose minutes field is "*", such
+	// as "* * * * *", which was often meant to activate once an hour.
+	WarnEveryMinute WarningCode = "every-minute"
+
+	// WarnSeconds is reported for a spec whose first field is the seconds
+	// and selects more than one second, such as "*/5 * * * * *", which was
+	// often written expecting the first field to be the minutes.
+	WarnSeconds WarningCode = "seconds"
+
+	// WarnSkippedMonths is reported for a spec whose days of the month do not
+	// occur in every month it selects, such as "0 0 31 * *", so that it skips
+	// those months.
+	WarnSkippedMonths WarningCode = "skipped-months"
+
+	// WarnDomOrDow is reported for a spec that restricts both the day of month
+	// and the day of week, such as "0 0 13 * FRI", so that it activates on
+	// days matching either of them.
+	WarnDomOrDow WarningCode = "dom-or-dow"
+
+	// WarnUnevenStep is reported for a step over the whole field that does not
+	// divide the field evenly, such as "*/7" in the minutes field, so that the
+	// gap from the last value back to the first is shorter than the step.
+	WarnUnevenStep WarningCode = "uneven-step"
+)
+
+// A Warning describes a part of a valid spec that is probably not what was
+// meant.
+type Warning struct {
+	Code      WarningCode
+	Field     int    // 1-based position of the field, or 0 if the warning is not specific to one field
+	FieldName string // name of the field, e.g. "minutes"
+	Message   string
+}
+
+// String returns the message of the warning, prefixed by the field it refers
+// to, if any.
+func (w Warning) String() string {
+	if w.Field == 0 {
+		return w.Message
+	}
+	return fmt.Sprintf("field %d (%s): %s", w.Field, w.FieldName, w.Message)
+}
+
+// Lint parses the spec as Parse does and returns warnings about the parts of
+// it that are valid but probably not what was meant.  Descriptors draw no
+// warnings.  If the spec is not valid, the error is returned instead.
+func Lint(spec string) ([]Warning, error) {
+	sched, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	_, _, rest, _ := NewParser().parsePrefixes(spec)
+	s, ok := sched.(*SpecSchedule)
+	if !ok || strings.HasPrefix(rest, "@") {
+		return nil, nil
+	}
+
+	fields := strings.Fields(rest)
+	hasSeconds, _, _ := NewParser().optionalFields(fields)
+	offset := 1
+	if !hasSeconds {
+		fields = append([]string{"0"}, fields...)
+		offset = 0
+	}
+	var warnings []Warning
+	warn := func(code WarningCode, i int, format string, args ...any) {
+		warnings = append(warnings, Warning{
+			Code: code, Field: i + offset, FieldName: fieldBounds[i].field, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if n := bits.OnesCount64(s.Second &^ starBit); hasSeconds && n > 1 {
+		warn(WarnSeconds, 0, "the spec has %d fields, so the first is the seconds: it activates %d times a minute",
+			len(strings.Fields(rest)), n)
+	}
+	if s.Minute&^starBit == all(minutes)&^starBit && bits.OnesCount64(s.Second&^starBit) == 1 {
+		warn(WarnEveryMinute, 1,
+			"activates every minute of each hour it selects; use a single minute, e.g. 0, to activate once an hour")
+	}
+	for i, r := range fieldBounds[:6] {
+		for _, expr := range strings.Split(fields[i], ",") {
+			if !strings.Contains(expr, "/") {
+				continue
+			}
+			start, end, step, _, err := parseRange(expr, r)
+			size := r.max - r.min + 1
+			if err != nil || start != r.min || end != r.max || size%step == 0 {
+				continue
+			}
+			last := start + (end-start)/step*step
+			warn(WarnUnevenStep, i,
+				"step %d does not divide the %d values evenly, so the gap from %d back to %d is %d rather than %d: %q",
+				step, size, last, start, size-(last-start), step, expr)
+		}
+	}
+	if skipped := skippedMonths(s); len(skipped) > 0 {
+		warn(WarnSkippedMonths, 3, "not every month has the days selected, so the schedule skips %s", strings.Join(skipped, ", "))
+	}
+	if !s.daysAnd() {
+		warn(WarnDomOrDow, 5,
+			"both the day of month and the day of week are restricted, so the schedule activates on days that match either of them")
+	}
+	slices.SortStableFunc(warnings, func(a, b Warning) int { return a.Field - b.Field })
+	return warnings, nil
+}
+
+// skippedMonths returns the names of the months the schedule selects that have
+// none of the days of the month it selects.  February is given as "February in
+// common years" if it has them in leap years only.
+func skippedMonths(s *SpecSchedule) []string {
+	days := (s.Dom | s.DomWeekday) &^ starBit
+	if !s.daysAnd() || s.DomLast || s.DomLastWeekday || days == 0 {
+		return nil
+	}
+	var skipped []string
+	for month := time.January; month <= time.December; month++ {
+		if 1<<uint(month)&s.Month == 0 || days&getBits(1, uint(daysInMonth(2001, month)), 1) > 0 {
+			continue
+		}
+		if month == time.February && days&(1<<29) > 0 {
+			skipped = append(skipped, "February in common years")
+			continue
+		}
+		skipped = append(skipped, month.String())
+	}
+	return skipped
+}
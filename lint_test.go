@@ -0,0 +1,90 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	entries := []struct {
+		spec     string
+		expected []Warning
+	}{
+		{"0 * * * *", nil},
+		{"@daily", nil},
+		{"@minutely", nil},
+		{"@every 5m", nil},
+		{"0 0 15 * *", nil},
+		{"*/15 * * * *", nil},
+		{"0 0 L * *", nil},
+		{"0 0 * * MON-FRI", nil},
+		{"TZ=UTC 0 9 * * *", nil},
+		{"* * * * *", []Warning{{WarnEveryMinute, 1, "minutes",
+			"activates every minute of each hour it selects; use a single minute, e.g. 0, to activate once an hour"}}},
+		{"0 * 9 * * *", []Warning{{WarnEveryMinute, 2, "minutes",
+			"activates every minute of each hour it selects; use a single minute, e.g. 0, to activate once an hour"}}},
+		{"*/5 0 * * * *", []Warning{{WarnSeconds, 1, "seconds",
+			"the spec has 6 fields, so the first is the seconds: it activates 12 times a minute"}}},
+		{"0 0 31 * *", []Warning{{WarnSkippedMonths, 3, "day-of-month",
+			"not every month has the days selected, so the schedule skips February, April, June, September, November"}}},
+		{"0 0 29 2 *", []Warning{{WarnSkippedMonths, 3, "day-of-month",
+			"not every month has the days selected, so the schedule skips February in common years"}}},
+		{"0 0 30,31 JAN-MAR *", []Warning{{WarnSkippedMonths, 3, "day-of-month",
+			"not every month has the days selected, so the schedule skips February"}}},
+		{"0 0 13 * FRI", []Warning{{WarnDomOrDow, 5, "day-of-week",
+			"both the day of month and the day of week are restricted, so the schedule activates on days that match either of them"}}},
+		{"0-59/7 0 * * *", []Warning{{WarnUnevenStep, 1, "minutes",
+			`step 7 does not divide the 60 values evenly, so the gap from 56 back to 0 is 4 rather than 7: "0-59/7"`}}},
+		{"0 0 */2 * *", []Warning{{WarnUnevenStep, 3, "day-of-month",
+			`step 2 does not divide the 31 values evenly, so the gap from 31 back to 1 is 1 rather than 2: "*/2"`}}},
+		{"0 0 * * */3", []Warning{{WarnUnevenStep, 5, "day-of-week",
+			`step 3 does not divide the 7 values evenly, so the gap from 6 back to 0 is 1 rather than 3: "*/3"`}}},
+		{"0 0 10-20/3 * *", nil},
+		{"0 0 31 * 1", []Warning{{WarnDomOrDow, 5, "day-of-week",
+			"both the day of month and the day of week are restricted, so the schedule activates on days that match either of them"}}},
+	}
+	for _, c := range entries {
+		actual, err := Lint(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+}
+
+func TestLintOrder(t *testing.T) {
+	actual, err := Lint("*/7 * * 13 * FRI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codes []WarningCode
+	for _, w := range actual {
+		codes = append(codes, w.Code)
+	}
+	expected := []WarningCode{WarnSeconds, WarnUnevenStep, WarnDomOrDow}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("got %v, expected %v", codes, expected)
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	for _, spec := range []string{"* * * *", "0 0 99 * *", "@bogus", "TZ=Bogus/Zone * * * * *"} {
+		warnings, err := Lint(spec)
+		if err == nil || warnings != nil {
+			t.Errorf("%s => expected only an error, got %v, %v", spec, warnings, err)
+		}
+	}
+}
+
+func TestWarningString(t *testing.T) {
+	w := Warning{WarnEveryMinute, 1, "minutes", "activates every minute"}
+	if s := w.String(); s != "field 1 (minutes): activates every minute" {
+		t.Errorf("got %q", s)
+	}
+	if s := (Warning{Message: "message"}).String(); s != "message" {
+		t.Errorf("got %q", s)
+	}
+}
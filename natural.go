@@ -0,0 +1,330 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseNatural returns a schedule for a simple English phrase, such as
+// "every 5 minutes", "every day at 3:30pm" or "every monday and thursday at
+// 09:00".  The phrase must follow this grammar, in any case:
+//
+//	phrase   = "every" interval { "at" times | "on" weekdays | "in" months }
+//	interval = [ number ] ( "second" | "minute" | "hour" | "day" ) [ "s" ]
+//	         | "weekday" | "weekend" | weekdays
+//	times    = time { ( "," | "and" ) time }
+//	time     = hour [ ":" minute ] [ "am" | "pm" ] | "noon" | "midnight"
+//	weekdays = weekday { ( "," | "and" ) weekday }
+//	months   = month { ( "," | "and" ) month }
+//
+// Days of the week and months may be given in full or by their first three
+// letters.  Intervals that evenly divide the minute, hour or day activate on
+// the clock, e.g. every 5 minutes activates at :00, :05 and so on, and every
+// day at midnight unless given times.  Other intervals, e.g. every 7 minutes
+// or every 2 days, are measured from when the schedule is first consulted and
+// may not be combined with clauses.
+//
+// Phrases that do not follow the grammar return an error saying how much of
+// the phrase was understood.
+func ParseNatural(phrase string) (Schedule, error) {
+	p := &naturalParser{phrase: phrase, tokens: tokenizeNatural(phrase)}
+	return p.parse()
+}
+
+// naturalParser holds the state of parsing a phrase for ParseNatural.
+type naturalParser struct {
+	phrase string
+	tokens []string
+	pos    int
+}
+
+// tokenizeNatural splits the phrase into lowercase words, with commas as words
+// of their own.
+func tokenizeNatural(phrase string) []string {
+	phrase = strings.ReplaceAll(strings.ToLower(phrase), ",", " , ")
+	return strings.Fields(phrase)
+}
+
+// peek returns the next token, or "" at the end of the phrase.
+func (p *naturalParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// next returns the next token and moves past it.
+func (p *naturalParser) next() string {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+// errorf returns an error naming the part of the phrase that was understood.
+func (p *naturalParser) errorf(format string, args ...interface{}) error {
+	understood := strings.Join(p.tokens[:p.pos], " ")
+	if understood == "" {
+		return fmt.Errorf("%s in %q", fmt.Sprintf(format, args...), p.phrase)
+	}
+	return fmt.Errorf("%s after %q in %q", fmt.Sprintf(format, args...), understood, p.phrase)
+}
+
+// naturalUnits are the units of an interval, with their length and the field
+// they step over.
+var naturalUnits = map[string]struct {
+	duration time.Duration
+	field    Field
+}{
+	"second": {time.Second, SecondField},
+	"minute": {time.Minute, MinuteField},
+	"hour":   {time.Hour, HourField},
+	"day":    {24 * time.Hour, DomField},
+}
+
+func (p *naturalParser) parse() (Schedule, error) {
+	if p.next() != "every" {
+		p.pos = 0
+		return nil, p.errorf(`expected "every"`)
+	}
+	s := &SpecSchedule{
+		Second:   1 << seconds.min,
+		Minute:   1 << minutes.min,
+		Hour:     1 << hours.min,
+		Dom:      all(dom),
+		Month:    all(months),
+		Dow:      all(dow),
+		Location: time.Local,
+	}
+
+	// The interval.
+	n := 1
+	if num, err := strconv.Atoi(p.peek()); err == nil {
+		p.next()
+		if num < 1 {
+			return nil, p.errorf("expected a positive number")
+		}
+		n = num
+	}
+	unit, ok := naturalUnits[strings.TrimSuffix(p.peek(), "s")]
+	subDaily, dayOfWeek := false, false
+	switch {
+	case ok:
+		p.next()
+		if unit.field == DomField {
+			if n > 1 {
+				return p.constantDelay(time.Duration(n) * unit.duration)
+			}
+			break
+		}
+		r := fieldBounds[unit.field]
+		if int(r.max-r.min+1)%n != 0 {
+			return p.constantDelay(time.Duration(n) * unit.duration)
+		}
+		subDaily = true
+		switch unit.field {
+		case SecondField:
+			s.Second = getBits(r.min, r.max, uint(n)) | starBit
+			s.Minute, s.Hour = all(minutes), all(hours)
+		case MinuteField:
+			s.Minute = getBits(r.min, r.max, uint(n)) | starBit
+			s.Hour = all(hours)
+		case HourField:
+			s.Hour = getBits(r.min, r.max, uint(n)) | starBit
+		}
+	case n > 1:
+		return nil, p.errorf(`expected "seconds", "minutes", "hours" or "days"`)
+	case p.peek() == "weekday" || p.peek() == "weekdays":
+		p.next()
+		s.Dow, dayOfWeek = getBits(uint(time.Monday), uint(time.Friday), 1), true
+	case p.peek() == "weekend" || p.peek() == "weekends":
+		p.next()
+		s.Dow, dayOfWeek = 1<<time.Saturday|1<<time.Sunday, true
+	default:
+		days, err := p.list(naturalWeekday, "a unit or a day of the week")
+		if err != nil {
+			return nil, err
+		}
+		s.Dow, dayOfWeek = days, true
+	}
+
+	// The clauses.
+	var times [][2]int
+	for p.peek() != "" {
+		switch p.next() {
+		case "at":
+			if subDaily || times != nil {
+				p.pos--
+				return nil, p.errorf(`unexpected "at"`)
+			}
+			for {
+				t, err := p.time()
+				if err != nil {
+					return nil, err
+				}
+				times = append(times, t)
+				if !p.separator() {
+					break
+				}
+			}
+		case "on":
+			if dayOfWeek {
+				p.pos--
+				return nil, p.errorf(`unexpected "on"`)
+			}
+			days, err := p.list(naturalWeekday, "a day of the week")
+			if err != nil {
+				return nil, err
+			}
+			s.Dow, dayOfWeek = days, true
+		case "in":
+			if s.Month&starBit == 0 {
+				p.pos--
+				return nil, p.errorf(`unexpected "in"`)
+			}
+			m, err := p.list(naturalMonth, "a month")
+			if err != nil {
+				return nil, err
+			}
+			s.Month = m
+		default:
+			p.pos--
+			return nil, p.errorf(`expected "at", "on" or "in", found %q`, p.peek())
+		}
+	}
+	return naturalTimes(s, times), nil
+}
+
+// constantDelay returns a ConstantDelaySchedule for the interval, which must
+// end the phrase.
+func (p *naturalParser) constantDelay(d time.Duration) (Schedule, error) {
+	if p.peek() != "" {
+		return nil, p.errorf("intervals that do not divide the clock evenly may not be followed by %q", p.peek())
+	}
+	return Every(d), nil
+}
+
+// separator moves past a list separator, returning false if there is none.
+func (p *naturalParser) separator() bool {
+	if p.peek() == "," || p.peek() == "and" {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// list parses a list of names into bits using lookup, which returns the value
+// of a name.
+func (p *naturalParser) list(lookup func(string) (int, bool), expected string) (uint64, error) {
+	var bits uint64
+	for {
+		v, ok := lookup(p.peek())
+		if !ok {
+			return 0, p.errorf("expected %s, found %q", expected, p.peek())
+		}
+		p.next()
+		bits |= 1 << uint(v)
+		if !p.separator() {
+			return bits, nil
+		}
+	}
+}
+
+// time parses a time of day into its hour and minute.
+func (p *naturalParser) time() ([2]int, error) {
+	tok := p.peek()
+	switch tok {
+	case "noon":
+		p.next()
+		return [2]int{12, 0}, nil
+	case "midnight":
+		p.next()
+		return [2]int{0, 0}, nil
+	}
+
+	suffix := ""
+	for _, s := range []string{"am", "pm"} {
+		if strings.HasSuffix(tok, s) {
+			tok, suffix = strings.TrimSuffix(tok, s), s
+		}
+	}
+	hourStr, minuteStr, hasMinute := strings.Cut(tok, ":")
+	hour, err := strconv.Atoi(hourStr)
+	minute := 0
+	if err == nil && hasMinute {
+		minute, err = strconv.Atoi(minuteStr)
+		if len(minuteStr) != 2 {
+			err = fmt.Errorf("minutes must have two digits")
+		}
+	}
+	if err != nil || minute < 0 || minute > 59 || hour < 0 || hour > 23 {
+		return [2]int{}, p.errorf("expected a time, found %q", p.peek())
+	}
+	p.next()
+	if suffix == "" && (p.peek() == "am" || p.peek() == "pm") {
+		suffix = p.next()
+	}
+	if suffix != "" {
+		if hour < 1 || hour > 12 {
+			return [2]int{}, p.errorf("expected an hour from 1 to 12 before %q", suffix)
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	}
+	return [2]int{hour, minute}, nil
+}
+
+// naturalTimes returns the schedule activating at the given times of day on
+// the schedule's days, or the schedule itself if there are no times.  Times
+// that do not share their minute are combined with Union, as a single
+// SpecSchedule would also activate at every other combination of their hours
+// and minutes.
+func naturalTimes(s *SpecSchedule, times [][2]int) Schedule {
+	if len(times) == 0 {
+		return s
+	}
+	byMinute := make(map[int]*SpecSchedule)
+	var union []Schedule
+	for _, t := range times {
+		spec, ok := byMinute[t[1]]
+		if !ok {
+			day := *s
+			spec = &day
+			spec.Minute, spec.Hour = 1<<uint(t[1]), 0
+			byMinute[t[1]] = spec
+			union = append(union, spec)
+		}
+		spec.Hour |= 1 << uint(t[0])
+	}
+	if len(union) == 1 {
+		return union[0]
+	}
+	return Union(union...)
+}
+
+// naturalWeekday returns the day of the week named by word.
+func naturalWeekday(word string) (int, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if word == name || word == name+"s" || word == name[:3] {
+			return int(d), true
+		}
+	}
+	return 0, false
+}
+
+// naturalMonth returns the month named by word.
+func naturalMonth(word string) (int, bool) {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if word == name || word == name[:3] {
+			return int(m), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,114 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNatural(t *testing.T) {
+	tests := []struct {
+		phrase, spec string
+	}{
+		{"every second", "* * * * * *"},
+		{"every 15 seconds", "*/15 * * * * *"},
+		{"every minute", "0 * * * * *"},
+		{"every 5 minutes", "0 */5 * * * *"},
+		{"every 2 hours", "0 0 */2 * * *"},
+		{"every hour in january", "0 0 * * JAN *"},
+		{"every day", "0 0 0 * * *"},
+		{"Every day at 3:30pm", "0 30 15 * * *"},
+		{"every day at 3 pm", "0 0 15 * * *"},
+		{"every day at noon", "0 0 12 * * *"},
+		{"every day at 12am", "0 0 0 * * *"},
+		{"every day at 9:00 and 17:00", "0 0 9,17 * * *"},
+		{"every weekday at 09:00", "0 0 9 * * MON-FRI"},
+		{"every weekend at 10am", "0 0 10 * * SAT,SUN"},
+		{"every monday and thursday at 09:15", "0 15 9 * * MON,THU"},
+		{"every mon, wed, fri", "0 0 0 * * MON,WED,FRI"},
+		{"every 10 minutes on tuesdays", "0 */10 * * * TUE"},
+		{"every day at 6am in jun, jul and aug", "0 0 6 * JUN-AUG *"},
+		{"every friday in december at midnight", "0 0 0 * DEC FRI"},
+	}
+	for _, c := range tests {
+		actual, err := ParseNatural(c.phrase)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.phrase, err)
+			continue
+		}
+		expected, err := Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Equal(actual, expected) {
+			t.Errorf("%s => %v, expected %s", c.phrase, actual, c.spec)
+		}
+	}
+}
+
+func TestParseNaturalDelay(t *testing.T) {
+	tests := []struct {
+		phrase   string
+		expected time.Duration
+	}{
+		{"every 7 minutes", 7 * time.Minute},
+		{"every 90 seconds", 90 * time.Second},
+		{"every 5 hours", 5 * time.Hour},
+		{"every 2 days", 48 * time.Hour},
+	}
+	for _, c := range tests {
+		actual, err := ParseNatural(c.phrase)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.phrase, err)
+			continue
+		}
+		if actual != Every(c.expected) {
+			t.Errorf("%s => %#v, expected %v", c.phrase, actual, c.expected)
+		}
+	}
+}
+
+func TestParseNaturalTimes(t *testing.T) {
+	sched, err := ParseNatural("every day at 9:00 and 17:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	expected := []time.Time{
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local),
+		time.Date(2024, 3, 1, 17, 30, 0, 0, time.Local),
+		time.Date(2024, 3, 2, 9, 0, 0, 0, time.Local),
+	}
+	for i, actual := range NextN(sched, from, len(expected)) {
+		if !actual.Equal(expected[i]) {
+			t.Errorf("activation %d: %v, expected %v", i, actual, expected[i])
+		}
+	}
+}
+
+func TestParseNaturalErrors(t *testing.T) {
+	tests := []struct {
+		phrase, err string
+	}{
+		{"", `expected "every" in ""`},
+		{"daily", `expected "every" in "daily"`},
+		{"every fortnight", `expected a unit or a day of the week, found "fortnight" after "every"`},
+		{"every 3 mondays", `expected "seconds", "minutes", "hours" or "days" after "every 3"`},
+		{"every 0 minutes", `expected a positive number after "every 0"`},
+		{"every 7 minutes on monday", `may not be followed by "on" after "every 7 minutes"`},
+		{"every 5 minutes at 9am", `unexpected "at" after "every 5 minutes"`},
+		{"every 2 days at 9am", `may not be followed by "at" after "every 2 days"`},
+		{"every monday on friday", `unexpected "on" after "every monday"`},
+		{"every day at 25:00", `expected a time, found "25:00" after "every day at"`},
+		{"every day at 9:5", `expected a time, found "9:5" after "every day at"`},
+		{"every day at 13pm", `expected an hour from 1 to 12 before "pm" after "every day at 13pm"`},
+		{"every day at 9am tomorrow", `expected "at", "on" or "in", found "tomorrow" after "every day at 9am"`},
+		{"every day in smarch", `expected a month, found "smarch" after "every day in"`},
+	}
+	for _, c := range tests {
+		_, err := ParseNatural(c.phrase)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%q => %v, expected error containing %q", c.phrase, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cron
+
+import "time"
+
+// OnceSchedule activates once, at a fixed time, and never again.  Unlike an
+// OnStartSchedule, its activation does not depend on when it is first
+// consulted.
+type OnceSchedule struct {
+	At time.Time
+}
+
+// Once returns a Schedule that activates once, at the given time.
+func Once(at time.Time) OnceSchedule {
+	return OnceSchedule{At: at}
+}
+
+// Next returns the activation if it comes after the given time, and the zero
+// time otherwise.
+func (schedule OnceSchedule) Next(t time.Time) time.Time {
+	if t.Before(schedule.At) {
+		return schedule.At
+	}
+	return time.Time{}
+}
+
+// Prev returns the activation if it came before the given time, and the zero
+// time otherwise.
+func (schedule OnceSchedule) Prev(t time.Time) time.Time {
+	if schedule.At.Before(t) {
+		return schedule.At
+	}
+	return time.Time{}
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule OnceSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
+
+// Matches returns true if the schedule activates at the given time.
+func (schedule OnceSchedule) Matches(t time.Time) bool {
+	return t.Equal(schedule.At)
+}
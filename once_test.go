@@ -0,0 +1,37 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnceSchedule(t *testing.T) {
+	at := getTime("Mon Jul 9 14:45:30 2012")
+	sched := Once(at)
+	tests := []struct {
+		t          string
+		next, prev time.Time
+	}{
+		{"Sun Jul 8 00:00 2012", at, time.Time{}},
+		{"Mon Jul 9 14:45:29.999 2012", at, time.Time{}},
+		{"Mon Jul 9 14:45:30 2012", time.Time{}, time.Time{}},
+		{"Mon Jul 9 14:45:30.001 2012", time.Time{}, at},
+		{"Tue Jul 10 00:00 2012", time.Time{}, at},
+	}
+	for _, c := range tests {
+		if actual := sched.Next(getTime(c.t)); !actual.Equal(c.next) {
+			t.Errorf("Next(%s) => (expected) %v != %v (actual)", c.t, c.next, actual)
+		}
+		if actual := sched.Prev(getTime(c.t)); !actual.Equal(c.prev) {
+			t.Errorf("Prev(%s) => (expected) %v != %v (actual)", c.t, c.prev, actual)
+		}
+	}
+	if !sched.Matches(at) || sched.Matches(at.Add(time.Second)) {
+		t.Error("expected the schedule to match its activation only")
+	}
+
+	from := at.Add(-time.Hour)
+	if actual := NextN(sched, from, 3); len(actual) != 1 || !actual[0].Equal(at) {
+		t.Errorf("NextN => %v, expected only %v", actual, at)
+	}
+}
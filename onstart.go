@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// OnStartSchedule activates once, as soon as it is first consulted, and never
+// again.  It backs the "@reboot" descriptor, which runs a job when the
+// scheduler starts.
+type OnStartSchedule struct {
+	mu        sync.Mutex
+	activated bool
+	at        time.Time
+}
+
+// Next returns the upcoming second the first time it is called.  Until that
+// activation has passed it keeps returning it, and after that it returns the
+// zero time.
+func (schedule *OnStartSchedule) Next(t time.Time) time.Time {
+	schedule.mu.Lock()
+	defer schedule.mu.Unlock()
+	if !schedule.activated {
+		schedule.activated = true
+		schedule.at = t.Add(1*time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
+	}
+	if t.Before(schedule.at) {
+		return schedule.at
+	}
+	return time.Time{}
+}
+
+// Prev returns the activation if it came before the given time, and the zero
+// time otherwise.
+func (schedule *OnStartSchedule) Prev(t time.Time) time.Time {
+	schedule.mu.Lock()
+	defer schedule.mu.Unlock()
+	if schedule.activated && schedule.at.Before(t) {
+		return schedule.at
+	}
+	return time.Time{}
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule *OnStartSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
+
+// String returns "@reboot".
+func (schedule *OnStartSchedule) String() string {
+	return "@reboot"
+}
@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnStartSchedule(t *testing.T) {
+	sched, err := Parse("TZ=Asia/Tokyo @reboot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := getTime("Mon Jul 9 14:45:00.005 2012")
+	expected := getTime("Mon Jul 9 14:45:01 2012")
+	if actual := sched.(PrevSchedule).Prev(start); !actual.IsZero() {
+		t.Errorf("Prev before activation: (expected) zero != %v (actual)", actual)
+	}
+	if actual := sched.Next(start); actual != expected {
+		t.Errorf("first Next: (expected) %v != %v (actual)", expected, actual)
+	}
+	if actual := sched.Next(start.Add(500 * time.Millisecond)); actual != expected {
+		t.Errorf("Next before activation: (expected) %v != %v (actual)", expected, actual)
+	}
+	for _, after := range []time.Duration{time.Second, time.Minute, 24 * time.Hour} {
+		if actual := sched.Next(expected.Add(after - time.Second)); !actual.IsZero() {
+			t.Errorf("Next after activation: (expected) zero != %v (actual)", actual)
+		}
+	}
+	if actual := sched.(PrevSchedule).Prev(expected.Add(time.Hour)); actual != expected {
+		t.Errorf("Prev after activation: (expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+func TestOnStartScheduleIndependent(t *testing.T) {
+	first, _ := Parse("@reboot")
+	second, _ := Parse("@reboot")
+	now := getTime("Mon Jul 9 14:45 2012")
+	first.Next(now)
+	first.Next(now.Add(time.Hour))
+	if actual := second.Next(now.Add(time.Hour)); actual.IsZero() {
+		t.Error("expected each parsed @reboot to activate once")
+	}
+}
+
+func TestOnStartScheduleString(t *testing.T) {
+	if s := MustParse("@reboot"); s.(*OnStartSchedule).String() != "@reboot" {
+		t.Errorf("unexpected string: %s", s)
+	}
+}
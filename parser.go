@@ -1,11 +1,15 @@
 package cron
 
 import (
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Parse returns a new crontab schedule representing the given spec.
@@ -13,68 +17,702 @@ import (
 //
 // It accepts
 //   - Full crontab specs, e.g. "* * * * * ?"
+//   - Full crontab specs with a year, e.g. "0 0 12 1 1 ? 2027"
 //   - Descriptors, e.g. "@midnight", "@every 1h30m"
+//
+// Any of these may be prefixed by a time zone, either as "TZ=UTC" or as
+// "CRON_TZ=UTC", and by the policy prefixes "DOMDOW=AND" or "DOMDOW=OR",
+// "GAP=SKIP" or "GAP=AFTER" and "REPEAT=BOTH" or "REPEAT=FIRST", which override
+// the parser's DomDowPolicy, GapPolicy and RepeatPolicy.
+//
+// Parse is equivalent to NewParser().Parse.
 func Parse(spec string) (Schedule, error) {
-	// Extract timezone if present
-	var loc = time.Local
-	var err error
-	if strings.HasPrefix(spec, "TZ=") {
-		i := strings.Index(spec, " ")
-		if loc, err = time.LoadLocation(spec[3:i]); err != nil {
-			return nil, fmt.Errorf("Provided bad location %s: %v", spec[3:i], err)
-		}
-		spec = strings.TrimSpace(spec[i:])
+	return NewParser().Parse(spec)
+}
+
+// ParseStandard returns a new crontab schedule representing the given
+// standard (POSIX) spec, which has exactly 5 fields: minute, hour, day of month,
+// month and day of week.  Unlike Parse, it rejects a seconds field rather than
+// quietly running every second.  Time zone prefixes and descriptors are
+// accepted.
+func ParseStandard(spec string) (Schedule, error) {
+	return NewParser(WithoutSeconds(), WithoutYear()).Parse(spec)
+}
+
+// ParseQuartz returns a new crontab schedule representing the given spec,
+// interpreted the way the Quartz scheduler does.  See WithQuartz.
+func ParseQuartz(spec string) (Schedule, error) {
+	return NewParser(WithQuartz()).Parse(spec)
+}
+
+// ParseWithHash returns a new crontab schedule representing the given spec,
+// in which any field may use the "H" (hash) syntax.  See WithHash.
+func ParseWithHash(spec, seed string) (Schedule, error) {
+	return NewParser(WithHash(seed)).Parse(spec)
+}
+
+// Validate returns the error that Parse would return for the given spec, or nil
+// if the spec is valid.  It does not construct a schedule.
+func Validate(spec string) error {
+	return NewParser().Validate(spec)
+}
+
+// ValidateStandard returns the error that ParseStandard would return for the
+// given spec, or nil if the spec is valid.
+func ValidateStandard(spec string) error {
+	return NewParser(WithoutSeconds(), WithoutYear()).Validate(spec)
+}
+
+// Errors reported (wrapped in a *ParseError) for expressions that are
+// well-formed but meaningless.
+var (
+	ErrEmptyListElement = errors.New("empty element in list")
+	ErrZeroStep         = errors.New("step must be a positive integer")
+	ErrUnsatisfiable    = errors.New("spec can never activate")
+)
+
+// MustParse is like Parse but panics if the spec cannot be parsed.  It
+// simplifies safe initialization of global variables holding schedules.
+func MustParse(spec string) Schedule {
+	schedule, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
+
+// ParseError describes a spec that could not be parsed.
+type ParseError struct {
+	Spec      string // the spec as given to the parser
+	Field     int    // 1-based position of the offending field, or 0 if the error is not specific to one field
+	FieldName string // name of the offending field, e.g. "hours"
+	Cause     error  // the underlying error
+}
+
+func (e *ParseError) Error() string {
+	if e.Field == 0 {
+		return e.Cause.Error()
+	}
+	return fmt.Sprintf("failed to parse field %d (%s): %v", e.Field, e.FieldName, e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
+
+// fieldMode describes whether an optional field may, must or must not be
+// given.
+type fieldMode int
+
+const (
+	fieldOptional fieldMode = iota
+	fieldRequired
+	fieldForbidden
+)
+
+// A Parser parses crontab specs in the dialect selected by its options.  The
+// zero value parses the same dialect as Parse.
+type Parser struct {
+	seconds       fieldMode
+	year          fieldMode
+	noDescriptors bool
+	noEvery       bool
+	quartz        bool
+	hash          bool
+	seed          string
+	strictRanges  bool
+	lenientNames  bool
+	location      *time.Location
+	gap           GapPolicy
+	repeat        RepeatPolicy
+	satisfiable   bool
+	domDow        DomDowPolicy
+}
+
+// ParseOption configures a Parser.
+type ParseOption func(*Parser)
+
+// NewParser returns a Parser configured by the given options.  Without any
+// options, it accepts everything that Parse does.
+func NewParser(opts ...ParseOption) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithSeconds requires specs to begin with a seconds field.
+func WithSeconds() ParseOption {
+	return func(p *Parser) {
+		p.seconds = fieldRequired
+	}
+}
+
+// WithoutSeconds rejects specs that begin with a seconds field, so that all
+// specs activate on the minute.
+func WithoutSeconds() ParseOption {
+	return func(p *Parser) {
+		p.seconds = fieldForbidden
+	}
+}
+
+// WithYear requires specs to end with a year field.
+func WithYear() ParseOption {
+	return func(p *Parser) {
+		p.year = fieldRequired
+	}
+}
+
+// WithoutYear rejects specs that end with a year field.
+func WithoutYear() ParseOption {
+	return func(p *Parser) {
+		p.year = fieldForbidden
+	}
+}
+
+// WithoutDescriptors rejects descriptors such as "@daily" and "@every 1h".
+func WithoutDescriptors() ParseOption {
+	return func(p *Parser) {
+		p.noDescriptors = true
+	}
+}
+
+// WithoutEvery rejects "@every" descriptors, while still accepting the others.
+func WithoutEvery() ParseOption {
+	return func(p *Parser) {
+		p.noEvery = true
+	}
+}
+
+// WithQuartz interprets specs the way the Quartz scheduler does:
+//   - The seconds field is required, and the year field is optional.
+//   - Exactly one of day-of-month and day-of-week must be "?", meaning no
+//     specific value, and the schedule is restricted by the other one only.
+//   - Day-of-week is numbered 1-7 starting from SUN, and a bare "L" in it
+//     stands for SAT.
+//   - Descriptors are not accepted.
+func WithQuartz() ParseOption {
+	return func(p *Parser) {
+		p.quartz = true
+		p.seconds = fieldRequired
+		p.noDescriptors = true
+	}
+}
+
+// WithHash allows any field to use the "H" (hash) syntax to spread schedules
+// out:
+//   - "H" stands for a single value within the field's range
+//   - "H(N-M)" stands for a single value within N-M
+//   - "H/step" and "H(N-M)/step" stand for every step, starting at an offset
+//     within the first step
+// The values are derived from the seed rather than chosen at random, so the
+// same seed and spec always produce the same schedule.  In the day-of-month
+// field, a bare "H" chooses from 1-28 so that it activates in every month.
+func WithHash(seed string) ParseOption {
+	return func(p *Parser) {
+		p.hash = true
+		p.seed = seed
+	}
+}
+
+// WithLocation interprets specs without a time zone prefix in loc instead of
+// the machine's local time zone.
+func WithLocation(loc *time.Location) ParseOption {
+	return func(p *Parser) {
+		p.location = loc
+	}
+}
+
+// WithGapPolicy sets the GapPolicy of the parsed schedules, which selects what
+// happens to activations at wall clock times skipped by daylight savings.  A
+// "GAP=SKIP" or "GAP=AFTER" prefix on the spec overrides it.
+func WithGapPolicy(policy GapPolicy) ParseOption {
+	return func(p *Parser) {
+		p.gap = policy
+	}
+}
+
+// WithRepeatPolicy sets the RepeatPolicy of the parsed schedules, which selects
+// what happens to activations at wall clock times repeated by daylight savings.
+// A "REPEAT=BOTH" or "REPEAT=FIRST" prefix on the spec overrides it.
+func WithRepeatPolicy(policy RepeatPolicy) ParseOption {
+	return func(p *Parser) {
+		p.repeat = policy
+	}
+}
+
+// WithDomDowPolicy sets the DomDowPolicy of the parsed schedules, which
+// selects whether days must satisfy either or both of a restricted
+// day-of-month and day-of-week.  A "DOMDOW=AND" or "DOMDOW=OR" prefix on the
+// spec overrides it.
+func WithDomDowPolicy(policy DomDowPolicy) ParseOption {
+	return func(p *Parser) {
+		p.domDow = policy
+	}
+}
+
+// WithUnsatisfiableCheck rejects specs that can never activate, such as
+// "0 0 0 31 2 *", with an error wrapping ErrUnsatisfiable.  See Satisfiable.
+func WithUnsatisfiableCheck() ParseOption {
+	return func(p *Parser) {
+		p.satisfiable = true
+	}
+}
+
+// WithStrictRanges rejects ranges whose beginning is beyond their end, such as
+// "22-2", instead of wrapping them around the end of the field.
+func WithStrictRanges() ParseOption {
+	return func(p *Parser) {
+		p.strictRanges = true
+	}
+}
+
+// WithLenientNames accepts full month and day-of-week names, such as "January"
+// and "Monday", and the common abbreviations "Sept", "Tues", "Thur" and
+// "Thurs", as well as the usual three-letter names.
+func WithLenientNames() ParseOption {
+	return func(p *Parser) {
+		p.lenientNames = true
+	}
+}
+
+// Parse returns a new crontab schedule representing the given spec.
+// It returns a descriptive error if the spec is not valid in the parser's
+// dialect.  Errors are of type *ParseError.
+func (p *Parser) Parse(spec string) (Schedule, error) {
+	schedule := new(SpecSchedule)
+	descriptor, err := p.parse(spec, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if descriptor != nil {
+		return descriptor, nil
+	}
+	return schedule, nil
+}
+
+// Validate returns the error that Parse would return for the given spec, or
+// nil if the spec is valid.  It is suitable for checking specs as they are
+// typed, as it does not construct a schedule.
+func (p *Parser) Validate(spec string) error {
+	var schedule SpecSchedule
+	_, err := p.parse(spec, &schedule)
+	return err
+}
+
+// parse parses the given spec into schedule.  If the spec is a descriptor, the
+// schedule it stands for is returned instead.
+func (p *Parser) parse(spec string, schedule *SpecSchedule) (Schedule, error) {
+	orig := spec
+	fail := func(err error) (Schedule, error) {
+		return nil, &ParseError{Spec: orig, Cause: err}
+	}
+
+	// Extract timezone and policies if present
+	p, loc, spec, err := p.parsePrefixes(spec)
+	if err != nil {
+		return fail(err)
 	}
 
 	// Handle named schedules (descriptors)
 	if strings.HasPrefix(spec, "@") {
-		return parseDescriptor(spec, loc)
+		if p.noDescriptors {
+			return fail(fmt.Errorf("descriptors are not accepted: %q", spec))
+		}
+		if p.noEvery && isEvery(spec) {
+			return fail(fmt.Errorf("@every is not accepted: %q", spec))
+		}
+		descriptor, err := parseDescriptor(spec, loc)
+		if err != nil {
+			return fail(err)
+		}
+		if spec, ok := descriptor.(*SpecSchedule); ok {
+			spec.DomDow, spec.Gap, spec.Repeat = p.domDow, p.gap, p.repeat
+		}
+		return descriptor, nil
 	}
 
-	// Split on whitespace.  We require 5 or 6 fields.
-	// (second, optional) (minute) (hour) (day of month) (month) (day of week)
+	// Split on whitespace.  We require 5 to 7 fields, depending on the options.
+	// (second, optional) (minute) (hour) (day of month) (month) (day of week) (year, optional)
 	fields := strings.Fields(spec)
-	if len(fields) != 5 && len(fields) != 6 {
-		return nil, fmt.Errorf("Expected 5 or 6 fields, found %d: %s", len(fields), spec)
+
+	// A list split by a space, e.g. "1, 5", would otherwise be read as two
+	// fields.
+	for i := 1; i < len(fields); i++ {
+		prev, cur := fields[i-1], fields[i]
+		if prev != "," && cur != "," && (strings.HasSuffix(prev, ",") || strings.HasPrefix(cur, ",")) {
+			return fail(fmt.Errorf("%w, as lists may not contain spaces: %q", ErrEmptyListElement, prev+" "+cur))
+		}
+	}
+	hasSeconds, _, err := p.optionalFields(fields)
+	if err != nil {
+		return fail(fmt.Errorf("%v: %q", err, spec))
 	}
 
 	// Add 0 for second field if necessary.
-	if len(fields) == 5 {
+	if !hasSeconds {
 		fields = append([]string{"0"}, fields...)
 	}
-	var schedule *SpecSchedule
-	{
-		fieldValues := []struct {
-			f uint64
-			b bounds
-		}{
-			{b: seconds},
-			{b: minutes},
-			{b: hours},
-			{b: dom},
-			{b: months},
-			{b: dow},
-		}
-		for i, val := range fieldValues {
-			var err error
-			val.f, err = getField(fields[i], val.b)
+	if p.quartz {
+		if err = checkQuartzFields(fields); err != nil {
+			return fail(err)
+		}
+	}
+	// Report fields by their 1-based position in the spec as written.
+	offset := 1
+	if !hasSeconds {
+		offset = 0
+	}
+	fieldError := func(i int, err error) error {
+		return &ParseError{Spec: orig, Field: i + offset, FieldName: fieldBounds[i].field, Cause: err}
+	}
+	for i, r := range fieldBounds[:6] {
+		if !isHashField(fields[i]) {
+			continue
+		}
+		if !p.hash {
+			return nil, fieldError(i, fmt.Errorf("H is only supported by WithHash: %q", fields[i]))
+		}
+		if fields[i], err = hashField(fields[i], p.bounds(r), p.hashKey(i)); err != nil {
+			return nil, fieldError(i, err)
+		}
+	}
+	*schedule = SpecSchedule{Location: loc, DomDow: p.domDow, Gap: p.gap, Repeat: p.repeat}
+	for i, field := range fields {
+		switch i {
+		case 0:
+			schedule.Second, err = getField(field, p.bounds(seconds))
+		case 1:
+			schedule.Minute, err = getField(field, p.bounds(minutes))
+		case 2:
+			schedule.Hour, err = getField(field, p.bounds(hours))
+		case 3:
+			err = p.getDomField(field, schedule)
+		case 4:
+			schedule.Month, err = getField(field, p.bounds(months))
+		case 5:
+			err = p.getDowField(field, schedule)
+		case 6:
+			schedule.Year, err = getYearField(field)
+		}
+		if err != nil {
+			return nil, fieldError(i, err)
+		}
+	}
+	if p.satisfiable && !Satisfiable(schedule) {
+		return fail(fmt.Errorf("%w: %s never coincide: %q", ErrUnsatisfiable, conflictingFields(schedule), spec))
+	}
+
+	return nil, nil
+}
+
+// parsePrefixes splits the leading time zone and policy prefixes from spec, in
+// any order, returning a copy of the parser with the policies they give, the
+// location and the rest of the spec.  Without a time zone prefix, the location
+// is the parser's, or time.Local if it has none.
+func (p *Parser) parsePrefixes(spec string) (*Parser, *time.Location, string, error) {
+	q := *p
+	loc := time.Local
+	if p.location != nil {
+		loc = p.location
+	}
+	for {
+		var (
+			value string
+			err   error
+		)
+		spec = strings.TrimSpace(spec)
+		key, _, _ := strings.Cut(spec, "=")
+		switch key {
+		case "TZ", "CRON_TZ":
+			loc, spec, err = parseLocationPrefix(spec, loc)
+		case "DOMDOW":
+			if value, spec, err = cutPrefix(key, spec); err == nil {
+				q.domDow, err = parseDomDowPolicy(value)
+			}
+		case "GAP":
+			if value, spec, err = cutPrefix(key, spec); err == nil {
+				q.gap, err = parseGapPolicy(value)
+			}
+		case "REPEAT":
+			if value, spec, err = cutPrefix(key, spec); err == nil {
+				q.repeat, err = parseRepeatPolicy(value)
+			}
+		default:
+			return &q, loc, spec, nil
+		}
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+}
+
+// cutPrefix splits a leading "key=value" prefix from spec, returning the value
+// and the rest of the spec.
+func cutPrefix(key, spec string) (value, rest string, err error) {
+	prefix := spec
+	if i := strings.IndexFunc(spec, unicode.IsSpace); i >= 0 {
+		prefix, rest = spec[:i], strings.TrimSpace(spec[i:])
+	}
+	value = prefix[len(key)+1:]
+	if value == "" {
+		return "", "", fmt.Errorf("missing value after %s=: %q", key, spec)
+	}
+	if rest == "" {
+		return "", "", fmt.Errorf("missing schedule after %s: %q", prefix, spec)
+	}
+	return value, rest, nil
+}
+
+// parseDomDowPolicy returns the DomDowPolicy named by the value of a DOMDOW
+// prefix.
+func parseDomDowPolicy(value string) (DomDowPolicy, error) {
+	switch strings.ToUpper(value) {
+	case "OR":
+		return DomOrDow, nil
+	case "AND":
+		return DomAndDow, nil
+	}
+	return 0, fmt.Errorf("unknown DOMDOW policy %q, expected AND or OR", value)
+}
+
+// parseGapPolicy returns the GapPolicy named by the value of a GAP prefix.
+func parseGapPolicy(value string) (GapPolicy, error) {
+	switch strings.ToUpper(value) {
+	case "AFTER":
+		return RunAfterGap, nil
+	case "SKIP":
+		return SkipGap, nil
+	}
+	return 0, fmt.Errorf("unknown GAP policy %q, expected AFTER or SKIP", value)
+}
+
+// parseRepeatPolicy returns the RepeatPolicy named by the value of a REPEAT
+// prefix.
+func parseRepeatPolicy(value string) (RepeatPolicy, error) {
+	switch strings.ToUpper(value) {
+	case "FIRST":
+		return RunFirstRepeat, nil
+	case "BOTH":
+		return RunBothRepeats, nil
+	}
+	return 0, fmt.Errorf("unknown REPEAT policy %q, expected FIRST or BOTH", value)
+}
+
+// parseLocationPrefix splits a leading "TZ=zone" or "CRON_TZ=zone" from spec,
+// returning the location it names and the rest of the spec.  The zone name may
+// be quoted, e.g. TZ="America/New_York".  If spec has no such prefix, loc is
+// returned with the spec unchanged but for surrounding whitespace.
+func parseLocationPrefix(spec string, loc *time.Location) (*time.Location, string, error) {
+	spec = strings.TrimSpace(spec)
+	var prefix string
+	for _, p := range []string{"TZ=", "CRON_TZ="} {
+		if strings.HasPrefix(spec, p) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		return loc, spec, nil
+	}
+	zone, rest := spec[len(prefix):], ""
+	if i := strings.IndexFunc(zone, unicode.IsSpace); i >= 0 {
+		zone, rest = zone[:i], strings.TrimSpace(zone[i:])
+	}
+	if n := len(zone); n >= 2 && (zone[0] == '"' || zone[0] == '\'') && zone[n-1] == zone[0] {
+		zone = zone[1 : n-1]
+	} else if strings.ContainsAny(zone, `"'`) {
+		return nil, "", fmt.Errorf("unbalanced quotes in location: %q", spec)
+	}
+	if zone == "" {
+		return nil, "", fmt.Errorf("missing location after %s: %q", prefix, spec)
+	}
+	if rest == "" {
+		return nil, "", fmt.Errorf("missing schedule after location: %q", spec)
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, "", fmt.Errorf("provided bad location %q: %v", zone, err)
+	}
+	return loc, rest, nil
+}
+
+// conflictingFields names the fields of an unsatisfiable schedule that rule
+// out every day, e.g. "day-of-month and month".
+func conflictingFields(s *SpecSchedule) string {
+	var names []string
+	if s.Dom&starBit == 0 || s.DomLast || s.DomLastWeekday || s.DomWeekday > 0 {
+		names = append(names, dom.field)
+	}
+	if s.Dow&starBit == 0 {
+		names = append(names, dow.field)
+	}
+	names = append(names, months.field)
+	if !s.Year.isZero() {
+		without := *s
+		without.Year = yearSet{}
+		if Satisfiable(&without) {
+			// The days occur, just not in the given years.
+			names = names[:len(names)-1]
+		}
+		names = append(names, years.field)
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// bounds returns the given bounds adjusted for the parser's options.
+func (p *Parser) bounds(r bounds) bounds {
+	r.wrap = !p.strictRanges
+	r.lenient = p.lenientNames
+	return r
+}
+
+// optionalFields returns whether a spec with the given fields has a seconds
+// field and a year field.  When both are optional, a single extra field is
+// taken to be the seconds.
+func (p *Parser) optionalFields(fields []string) (hasSeconds, hasYear bool, err error) {
+	n := len(fields)
+	min, max := 5, 5
+	for _, mode := range []fieldMode{p.seconds, p.year} {
+		if mode == fieldRequired {
+			min++
+		}
+		if mode != fieldForbidden {
+			max++
+		}
+	}
+	if n == max+1 && p.seconds == fieldForbidden {
+		return false, false, fmt.Errorf("this parser does not accept a seconds field, found %q before the minutes", fields[0])
+	}
+	if n < min || n > max {
+		switch {
+		case min == max:
+			return false, false, fmt.Errorf("expected %d fields, found %d", min, n)
+		case max-min == 1:
+			return false, false, fmt.Errorf("expected %d or %d fields, found %d", min, max, n)
+		default:
+			return false, false, fmt.Errorf("expected %d to %d fields, found %d", min, max, n)
+		}
+	}
+	extra := n - 5
+	hasSeconds = p.seconds == fieldRequired ||
+		p.seconds == fieldOptional && extra > 0 && !(p.year == fieldRequired && extra == 1)
+	if hasSeconds {
+		extra--
+	}
+	return hasSeconds, extra > 0, nil
+}
+
+// hashKey returns the key from which the hashed values of the given field are
+// chosen.
+func (p *Parser) hashKey(field int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(p.seed))
+	h.Write([]byte{0, byte(field)})
+	return h.Sum64()
+}
+
+// isHashField returns true if any of the field's ranges is an "H" expression.
+func isHashField(field string) bool {
+	for _, expr := range strings.Split(field, ",") {
+		if strings.HasPrefix(expr, "H") || strings.HasPrefix(expr, "h") {
+			return true
+		}
+	}
+	return false
+}
+
+// hashField returns the field with each "H" expression replaced by the range it
+// stands for, chosen by key.
+//   H [ "(" number "-" number ")" ] [ "/" number ]
+func hashField(field string, r bounds, key uint64) (string, error) {
+	ranges := strings.Split(field, ",")
+	for i, expr := range ranges {
+		if !isHashField(expr) {
+			continue
+		}
+		var (
+			start, end = r.min, r.max
+			rest       = expr[1:]
+			err        error
+		)
+		if r.min == dom.min && r.max == dom.max {
+			// Stay within the days that every month has.
+			end = 28
+		}
+		if strings.HasPrefix(rest, "(") {
+			j := strings.Index(rest, ")")
+			if j < 0 {
+				return "", fmt.Errorf("missing ) in hash expression: %q", expr)
+			}
+			lowAndHigh := strings.Split(rest[1:j], "-")
+			if len(lowAndHigh) != 2 {
+				return "", fmt.Errorf("hash range must be of the form H(N-M): %q", expr)
+			}
+			if start, err = parseIntOrName(lowAndHigh[0], r); err != nil {
+				return "", err
+			}
+			if end, err = parseIntOrName(lowAndHigh[1], r); err != nil {
+				return "", err
+			}
+			if start < r.min || end > r.max || start > end {
+				return "", fmt.Errorf("hash range (%d-%d) outside of range (%d-%d): %q", start, end, r.min, r.max, expr)
+			}
+			rest = rest[j+1:]
+		}
+		switch {
+		case rest == "":
+			ranges[i] = strconv.Itoa(int(start + uint(key%uint64(end-start+1))))
+		case strings.HasPrefix(rest, "/"):
+			step, err := mustParseInt(rest[1:])
 			if err != nil {
-				return nil, err
+				return "", err
 			}
-			fieldValues[i] = val
-		}
-		schedule = &SpecSchedule{
-			Second:   fieldValues[0].f,
-			Minute:   fieldValues[1].f,
-			Hour:     fieldValues[2].f,
-			Dom:      fieldValues[3].f,
-			Month:    fieldValues[4].f,
-			Dow:      fieldValues[5].f,
-			Location: loc,
+			if step == 0 {
+				return "", fmt.Errorf("step of hash expression must be positive: %q", expr)
+			}
+			if !strings.HasPrefix(expr[1:], "(") {
+				end = r.max
+			}
+			offset := uint(key % uint64(step))
+			if offset > end-start {
+				offset = 0
+			}
+			ranges[i] = fmt.Sprintf("%d-%d/%d", start+offset, end, step)
+		default:
+			return "", fmt.Errorf("unexpected characters in hash expression: %q", expr)
 		}
 	}
+	return strings.Join(ranges, ","), nil
+}
 
-	return schedule, nil
+// checkQuartzFields returns an error unless exactly one of the day-of-month and
+// day-of-week fields is "?", and "?" appears nowhere else.
+func checkQuartzFields(fields []string) error {
+	for i, field := range fields {
+		if i != 3 && i != 5 && strings.Contains(field, "?") {
+			return fmt.Errorf("? is only allowed in the day-of-month and day-of-week fields: %q", field)
+		}
+	}
+	var (
+		domAny = fields[3] == "?"
+		dowAny = fields[5] == "?"
+	)
+	if domAny == dowAny {
+		return fmt.Errorf("exactly one of day-of-month and day-of-week must be ?: %q %q", fields[3], fields[5])
+	}
+	other := fields[3]
+	if domAny {
+		other = fields[5]
+	}
+	if strings.Contains(other, "?") {
+		return fmt.Errorf("? may not be combined with other values: %q", other)
+	}
+	return nil
 }
 
 // getField returns an Int with the bits set representing all of the times that
@@ -82,8 +720,17 @@ func Parse(spec string) (Schedule, error) {
 func getField(field string, r bounds) (uint64, error) {
 	// list = range {"," range}
 	var bits uint64
-	ranges := strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
+	ranges, err := splitList(field)
+	if err != nil {
+		return uint64(0), err
+	}
 	for _, expr := range ranges {
+		if isWeekdayExpr(expr) {
+			return uint64(0), fmt.Errorf("nearest weekday (W) is only allowed in the day-of-month field: %q", expr)
+		}
+		if strings.Contains(expr, "#") {
+			return uint64(0), fmt.Errorf("nth day of week (#) is only allowed in the day-of-week field: %q", expr)
+		}
 		rBits, err := getRange(expr, r)
 		if err != nil {
 			return uint64(0), err
@@ -93,36 +740,235 @@ func getField(field string, r bounds) (uint64, error) {
 	return bits, nil
 }
 
+// isWeekdayExpr returns true if expr is of the form NW or LW.
+func isWeekdayExpr(expr string) bool {
+	if len(expr) < 2 || expr[len(expr)-1] != 'W' && expr[len(expr)-1] != 'w' {
+		return false
+	}
+	day := expr[:len(expr)-1]
+	if strings.EqualFold(day, "L") {
+		return true
+	}
+	_, err := strconv.Atoi(day)
+	return err == nil
+}
+
+// getDomField sets the day-of-month bits on the schedule, along with any of the
+// special day-of-month values:
+//   L   the last day of the month
+//   NW  the weekday nearest to day N of the month
+//   LW  the last weekday of the month
+func (p *Parser) getDomField(field string, s *SpecSchedule) error {
+	ranges, err := splitList(field)
+	if err != nil {
+		return err
+	}
+	for _, expr := range ranges {
+		upper := strings.ToUpper(expr)
+		switch {
+		case upper == "L":
+			s.DomLast = true
+			continue
+		case upper == "LW":
+			s.DomLastWeekday = true
+			continue
+		case strings.HasSuffix(upper, "W"):
+			day := expr[:len(expr)-1]
+			if strings.ContainsAny(day, "*?-/") {
+				return fmt.Errorf("nearest weekday (W) may not be used with a range or step: %q", expr)
+			}
+			bits, err := getRange(day, p.bounds(dom))
+			if err != nil {
+				return err
+			}
+			s.DomWeekday |= bits
+			continue
+		}
+		bits, err := getRange(expr, p.bounds(dom))
+		if err != nil {
+			return err
+		}
+		s.Dom |= bits
+	}
+	return nil
+}
+
+// getDowField sets the day-of-week bits on the schedule, along with any of the
+// special day-of-week values:
+//   D#N  the Nth occurrence (1-5) of day D within the month
+//   DL   the last occurrence of day D within the month
+// Sunday may be given as either 0 or 7, unless the parser is in Quartz mode, in
+// which case the days are numbered 1-7 from Sunday and a bare L stands for
+// Saturday.
+func (p *Parser) getDowField(field string, s *SpecSchedule) error {
+	quartz := p.quartz
+	ranges, err := splitList(field)
+	if err != nil {
+		return err
+	}
+	for _, expr := range ranges {
+		if isWeekdayExpr(expr) {
+			return fmt.Errorf("nearest weekday (W) is only allowed in the day-of-month field: %q", expr)
+		}
+		if strings.EqualFold(expr, "L") {
+			if quartz {
+				s.Dow |= 1 << time.Saturday
+				continue
+			}
+			return fmt.Errorf("last day of week (L) must follow a day, e.g. 5L or FRIL: %q", expr)
+		}
+		if last := len(expr) - 1; expr[last] == 'L' || expr[last] == 'l' {
+			day, err := p.parseDay(expr[:last], expr)
+			if err != nil {
+				return err
+			}
+			s.DowLast |= 1 << day
+			continue
+		}
+		if i := strings.Index(expr, "#"); i >= 0 {
+			day, err := p.parseDay(expr[:i], expr)
+			if err != nil {
+				return err
+			}
+			n, err := mustParseInt(expr[i+1:])
+			if err != nil {
+				return err
+			}
+			if n < 1 || n > 5 {
+				return fmt.Errorf("occurrence of day of week (%d) must be between 1 and 5: %q", n, expr)
+			}
+			s.DowNth |= 1 << ((n-1)*7 + day)
+			continue
+		}
+
+		if quartz {
+			bits, err := getRange(expr, p.bounds(quartzDow))
+			if err != nil {
+				return err
+			}
+			s.Dow |= bits&starBit | (bits&^starBit)>>1
+			continue
+		}
+
+		// Only accept 7 when it is written out, so that "*" and "N/step"
+		// still end on Saturday.
+		r := dow
+		for _, day := range strings.Split(strings.Split(expr, "/")[0], "-") {
+			if day == "7" {
+				r = dowWithSeven
+			}
+		}
+		bits, err := getRange(expr, p.bounds(r))
+		if err != nil {
+			return err
+		}
+		if bits&(1<<7) > 0 {
+			bits = bits&^(1<<7) | 1<<0
+		}
+		s.Dow |= bits
+	}
+	return nil
+}
+
+// parseDay returns the single (possibly-named) day of week named by day.  The
+// full expression is used for error messages.
+func (p *Parser) parseDay(day, expr string) (uint, error) {
+	r := dowWithSeven
+	if p.quartz {
+		r = quartzDow
+	}
+	d, err := parseIntOrName(day, p.bounds(r))
+	if err != nil {
+		return 0, err
+	}
+	if d < r.min || d > r.max {
+		return 0, fmt.Errorf("day of week (%d) outside of range (%d-%d): %q", d, r.min, r.max, expr)
+	}
+	if p.quartz {
+		return d - 1, nil
+	}
+	return d % 7, nil
+}
+
+// getYearField returns the set of years represented by the given field.  A
+// bare star leaves the set empty, so that every year matches.
+func getYearField(field string) (yearSet, error) {
+	var set yearSet
+	ranges, err := splitList(field)
+	if err != nil {
+		return yearSet{}, err
+	}
+	for _, expr := range ranges {
+		start, end, step, extraStar, err := parseRange(expr, years)
+		if err != nil {
+			return yearSet{}, err
+		}
+		if extraStar > 0 && step == 1 {
+			return yearSet{}, nil
+		}
+		for year := start; year <= end; year += step {
+			set.add(year)
+		}
+	}
+	return set, nil
+}
+
+// splitList returns the comma-separated elements of field, none of which may be
+// empty.  There must be at least one element.
+func splitList(field string) ([]string, error) {
+	if field == "" {
+		return nil, fmt.Errorf("empty field")
+	}
+	ranges := strings.Split(field, ",")
+	for _, expr := range ranges {
+		if expr == "" {
+			return nil, fmt.Errorf("%w: %q", ErrEmptyListElement, field)
+		}
+	}
+	return ranges, nil
+}
+
 // getRange returns the bits indicated by the given expression:
 //   number | number "-" number [ "/" number ]
 func getRange(expr string, r bounds) (uint64, error) {
+	start, end, step, extraStar, err := parseRange(expr, r)
+	if err != nil {
+		return uint64(0), err
+	}
+	if start > end {
+		return getWrappedBits(start, end, step, r) | extraStar, nil
+	}
+	return getBits(start, end, step) | extraStar, nil
+}
+
+// parseRange returns the start, end and step indicated by the given expression,
+// along with the star bit if the expression began with a star.  If the bounds
+// wrap, start may be beyond end.
+func parseRange(expr string, r bounds) (start, end, step uint, extraStar uint64, err error) {
 	var (
-		start, end, step uint
-		rangeAndStep     = strings.Split(expr, "/")
-		lowAndHigh       = strings.Split(rangeAndStep[0], "-")
-		singleDigit      = len(lowAndHigh) == 1
-		extraStar        uint64
-		err              error
+		rangeAndStep = strings.Split(expr, "/")
+		lowAndHigh   = strings.Split(rangeAndStep[0], "-")
+		singleValue  = len(lowAndHigh) == 1
 	)
 	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
 		start = r.min
 		end = r.max
 		extraStar = starBit
 	} else {
-		start, err = parseIntOrName(lowAndHigh[0], r.names)
+		start, err = parseIntOrName(lowAndHigh[0], r)
 		if err != nil {
-			return uint64(0), err
+			return 0, 0, 0, 0, err
 		}
 		switch len(lowAndHigh) {
 		case 1:
 			end = start
 		case 2:
-			end, err = parseIntOrName(lowAndHigh[1], r.names)
+			end, err = parseIntOrName(lowAndHigh[1], r)
 			if err != nil {
-				return uint64(0), err
+				return 0, 0, 0, 0, err
 			}
 		default:
-			return uint64(0), fmt.Errorf("Too many hyphens: %s", expr)
+			return 0, 0, 0, 0, fmt.Errorf("too many hyphens: %q", expr)
 		}
 	}
 
@@ -130,55 +976,127 @@ func getRange(expr string, r bounds) (uint64, error) {
 	case 1:
 		step = 1
 	case 2:
+		if rangeAndStep[1] == "" {
+			return 0, 0, 0, 0, fmt.Errorf("%w: %q", ErrZeroStep, expr)
+		}
 		step, err = mustParseInt(rangeAndStep[1])
 		if err != nil {
-			return uint64(0), err
+			return 0, 0, 0, 0, err
+		}
+		if step == 0 {
+			return 0, 0, 0, 0, fmt.Errorf("%w: %q", ErrZeroStep, expr)
 		}
 
-		// Special handling: "N/step" means "N-max/step".
-		if singleDigit {
+		// Special handling: "N/step" means "N-max/step", where N may also be a
+		// name, e.g. "JAN/3" or "MON/2".
+		if singleValue {
 			end = r.max
 		}
 	default:
-		return uint64(0), fmt.Errorf("Too many slashes: %s", expr)
+		return 0, 0, 0, 0, fmt.Errorf("too many slashes: %q", expr)
 	}
 
 	if start < r.min {
-		return uint64(0), fmt.Errorf("Beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
+		return 0, 0, 0, 0, fmt.Errorf("beginning of range (%d) below minimum (%d): %q", start, r.min, expr)
 	}
 	if end > r.max {
-		return uint64(0), fmt.Errorf("End of range (%d) above maximum (%d): %s", end, r.max, expr)
+		return 0, 0, 0, 0, fmt.Errorf("end of range (%d) above maximum (%d): %q", end, r.max, expr)
 	}
-	if start > end {
-		return uint64(0), fmt.Errorf("Beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
+	if start > end && !r.wrap {
+		return 0, 0, 0, 0, fmt.Errorf("beginning of range (%d) beyond end of range (%d): %q", start, end, expr)
 	}
 
-	return getBits(start, end, step) | extraStar, nil
+	return start, end, step, extraStar, nil
 }
 
 // parseIntOrName returns the (possibly-named) integer contained in expr.
-func parseIntOrName(expr string, names map[string]uint) (uint, error) {
-	if names != nil {
-		if namedInt, ok := names[strings.ToLower(expr)]; ok {
-			return namedInt, nil
+func parseIntOrName(expr string, r bounds) (uint, error) {
+	if r.names == nil || !isName(expr) {
+		return mustParseInt(expr)
+	}
+	name := strings.ToLower(expr)
+	if r.lenient {
+		if alias, ok := nameAliases[name]; ok {
+			name = alias
+		}
+	}
+	if namedInt, ok := r.names[name]; ok {
+		return namedInt, nil
+	}
+
+	// Suggest either the name an alias stands for, if the parser does not
+	// accept aliases, or the closest name.
+	suggestion, ok := nameAliases[name]
+	if _, known := r.names[suggestion]; !known {
+		suggestion, ok = suggest(name, nameCandidates(r))
+	}
+	names := nameCandidates(bounds{names: r.names})
+	for i, name := range names {
+		names[i] = strings.ToUpper(name)
+	}
+	err := fmt.Errorf("unrecognized name %q, expected a number or one of %s", expr, strings.Join(names, ", "))
+	if ok {
+		err = fmt.Errorf("%v; did you mean %s?", err, strings.ToUpper(suggestion))
+	}
+	return 0, err
+}
+
+// nameCandidates returns the names accepted within the given bounds, in order
+// of their values, followed by any aliases accepted for them.
+func nameCandidates(r bounds) []string {
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int { return int(r.names[a]) - int(r.names[b]) })
+	if !r.lenient {
+		return names
+	}
+	n := len(names)
+	for alias, name := range nameAliases {
+		if _, ok := r.names[name]; ok {
+			names = append(names, alias)
 		}
 	}
-	return mustParseInt(expr)
+	slices.Sort(names[n:])
+	return names
+}
+
+// isName returns true if expr consists of letters only.
+func isName(expr string) bool {
+	return expr != "" && strings.IndexFunc(expr, func(r rune) bool { return !unicode.IsLetter(r) }) < 0
 }
 
 // mustParseInt parses the given expression as an int.
 func mustParseInt(expr string) (uint, error) {
 	num, err := strconv.Atoi(expr)
 	if err != nil {
-		return uint(0), fmt.Errorf("Failed to parse int from %s: %s", expr, err)
+		return uint(0), fmt.Errorf("failed to parse int from %q: %v", expr, err)
 	}
 	if num < 0 {
-		return uint(0), fmt.Errorf("Negative number (%d) not allowed: %s", num, expr)
+		return uint(0), fmt.Errorf("negative number (%d) not allowed: %q", num, expr)
 	}
 
 	return uint(num), nil
 }
 
+// getWrappedBits sets all bits from start up to the field's maximum and on from
+// its minimum up to end, stepping across the wrap as through a single range.
+func getWrappedBits(start, end, step uint, r bounds) uint64 {
+	var (
+		bits uint64
+		n    = r.max - r.min + 1
+	)
+	for i := start; i <= end+n; i += step {
+		v := i
+		if v > r.max {
+			v -= n
+		}
+		bits |= 1 << v
+	}
+	return bits
+}
+
 // getBits sets all bits in the range [min, max], modulo the given step size.
 func getBits(min, max, step uint) uint64 {
 	var bits uint64
@@ -203,7 +1121,16 @@ func all(r bounds) uint64 {
 // parseDescriptor returns a pre-defined schedule for the expression, or returns
 // an error if none match.
 func parseDescriptor(spec string, loc *time.Location) (Schedule, error) {
-	switch spec {
+	fields := strings.Fields(spec)
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	if name == "@every" {
+		return parseEvery(args, spec)
+	}
+	if len(args) > 0 && slices.Contains(descriptors, name) {
+		return nil, fmt.Errorf("descriptor %q takes no arguments, got %q", fields[0], strings.Join(args, " "))
+	}
+
+	switch name {
 	case "@yearly", "@annually":
 		return &SpecSchedule{
 			Second:   1 << seconds.min,
@@ -258,16 +1185,145 @@ func parseDescriptor(spec string, loc *time.Location) (Schedule, error) {
 			Dow:      all(dow),
 			Location: loc,
 		}, nil
+
+	case "@minutely":
+		return &SpecSchedule{
+			Second:   1 << seconds.min,
+			Minute:   all(minutes),
+			Hour:     all(hours),
+			Dom:      all(dom),
+			Month:    all(months),
+			Dow:      all(dow),
+			Location: loc,
+		}, nil
+
+	case "@weekday":
+		return &SpecSchedule{
+			Second:   1 << seconds.min,
+			Minute:   1 << minutes.min,
+			Hour:     1 << hours.min,
+			Dom:      all(dom),
+			Month:    all(months),
+			Dow:      getBits(uint(time.Monday), uint(time.Friday), 1),
+			Location: loc,
+		}, nil
+
+	case "@weekend":
+		return &SpecSchedule{
+			Second:   1 << seconds.min,
+			Minute:   1 << minutes.min,
+			Hour:     1 << hours.min,
+			Dom:      all(dom),
+			Month:    all(months),
+			Dow:      1<<time.Saturday | 1<<time.Sunday,
+			Location: loc,
+		}, nil
+
+	case "@reboot":
+		return &OnStartSchedule{}, nil
 	}
 
-	const every = "@every "
-	if strings.HasPrefix(spec, every) {
-		duration, err := time.ParseDuration(spec[len(every):])
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse duration %s: %s", spec, err)
-		}
+	if suggestion, ok := suggest(name, descriptors); ok {
+		return nil, fmt.Errorf("unrecognized descriptor %q, did you mean %s?", fields[0], suggestion)
+	}
+	return nil, fmt.Errorf("unrecognized descriptor: %q", spec)
+}
+
+// descriptors lists the names of the descriptors parseDescriptor accepts.
+var descriptors = []string{
+	"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight",
+	"@hourly", "@minutely", "@weekday", "@weekend", "@reboot", "@every",
+}
+
+// parseEvery parses the arguments of an interval descriptor, of the form
+// "@every <duration> [offset <duration>]".
+func parseEvery(args []string, spec string) (Schedule, error) {
+	switch {
+	case len(args) == 0:
+		return nil, fmt.Errorf("expected @every <duration> [offset <duration>]: %q", spec)
+	case len(args) > 1 && !strings.EqualFold(args[1], "offset"):
+		return nil, fmt.Errorf("expected offset after duration, got %q: %q", strings.Join(args[1:], " "), spec)
+	case len(args) == 2:
+		return nil, fmt.Errorf("missing duration after offset: %q", spec)
+	case len(args) > 3:
+		return nil, fmt.Errorf("unexpected %q after offset: %q", strings.Join(args[3:], " "), spec)
+	}
+	duration, err := parseDuration(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse duration %q in %q: %v", args[0], spec, err)
+	}
+	if len(args) == 1 {
 		return Every(duration), nil
 	}
+	offset, err := parseDuration(args[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse offset %q in %q: %v", args[2], spec, err)
+	}
+	return EveryAligned(duration, offset), nil
+}
+
+// every is the prefix of an interval descriptor.
+const every = "@every "
+
+// isEvery returns true if spec is an interval descriptor.  Descriptor names are
+// case insensitive.
+func isEvery(spec string) bool {
+	fields := strings.Fields(spec)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "@every")
+}
 
-	return nil, fmt.Errorf("Unrecognized descriptor: %s", spec)
+// parseDuration is like time.ParseDuration, but also accepts the units "d" and
+// "w", which stand for a fixed 24 hours and 168 hours respectively, e.g.
+// "1w2d12h".
+func parseDuration(s string) (time.Duration, error) {
+	if !strings.ContainsAny(s, "dw") {
+		return time.ParseDuration(s)
+	}
+	var (
+		orig = s
+		neg  = false
+		d    time.Duration
+	)
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	isNumber := func(r rune) bool { return r >= '0' && r <= '9' || r == '.' }
+	for s != "" {
+		// Split off the next number and unit.
+		i := strings.IndexFunc(s, func(r rune) bool { return !isNumber(r) })
+		if i < 0 {
+			return 0, fmt.Errorf("missing unit in duration %q", orig)
+		}
+		j := len(s)
+		if k := strings.IndexFunc(s[i:], isNumber); k >= 0 {
+			j = i + k
+		}
+
+		var unit time.Duration
+		switch s[i:j] {
+		case "d":
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		default:
+			part, err := time.ParseDuration(s[:j])
+			if err != nil {
+				return 0, err
+			}
+			d += part
+			s = s[j:]
+			continue
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		d += time.Duration(n * float64(unit))
+		s = s[j:]
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
 }
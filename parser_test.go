@@ -1,7 +1,10 @@
 package cron
 
 import (
+	"errors"
+	"math/bits"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -29,7 +32,7 @@ func TestRange(t *testing.T) {
 	}
 
 	for _, c := range ranges {
-		actual, err := getRange(c.expr, bounds{c.min, c.max, nil})
+		actual, err := getRange(c.expr, bounds{min: c.min, max: c.max})
 		if err != nil {
 			t.Errorf("%s => (expected) nil != %d (actual)", c.expr, err)
 		}
@@ -52,7 +55,7 @@ func TestField(t *testing.T) {
 	}
 
 	for _, c := range fields {
-		actual, err := getField(c.expr, bounds{c.min, c.max, nil})
+		actual, err := getField(c.expr, bounds{min: c.min, max: c.max})
 		if err != nil {
 			t.Errorf("%s => (expected) nil != %d (actual)", c.expr, err)
 		}
@@ -102,6 +105,122 @@ func TestBits(t *testing.T) {
 	}
 }
 
+func TestDomField(t *testing.T) {
+	fields := []struct {
+		expr     string
+		expected SpecSchedule
+	}{
+		{"5", SpecSchedule{Dom: 1 << 5}},
+		{"*", SpecSchedule{Dom: all(dom)}},
+		{"L", SpecSchedule{DomLast: true}},
+		{"l", SpecSchedule{DomLast: true}},
+		{"15,L", SpecSchedule{Dom: 1 << 15, DomLast: true}},
+		{"L,1-2", SpecSchedule{Dom: 1<<1 | 1<<2, DomLast: true}},
+		{"15W", SpecSchedule{DomWeekday: 1 << 15}},
+		{"1w,15W,20", SpecSchedule{Dom: 1 << 20, DomWeekday: 1<<1 | 1<<15}},
+		{"LW", SpecSchedule{DomLastWeekday: true}},
+		{"L,LW", SpecSchedule{DomLast: true, DomLastWeekday: true}},
+	}
+
+	for _, c := range fields {
+		var actual SpecSchedule
+		if err := (&Parser{}).getDomField(c.expr, &actual); err != nil {
+			t.Errorf("%s => (expected) nil != %s (actual)", c.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+}
+
+func TestDowField(t *testing.T) {
+	fields := []struct {
+		expr     string
+		expected SpecSchedule
+	}{
+		{"5", SpecSchedule{Dow: 1 << 5}},
+		{"*", SpecSchedule{Dow: all(dow)}},
+		{"MON#2", SpecSchedule{DowNth: 1 << (7 + 1)}},
+		{"0#1", SpecSchedule{DowNth: 1 << 0}},
+		{"sat#5", SpecSchedule{DowNth: 1 << (28 + 6)}},
+		{"MON,FRI#1", SpecSchedule{Dow: 1 << 1, DowNth: 1 << 5}},
+		{"5L", SpecSchedule{DowLast: 1 << 5}},
+		{"FRIL,sunl", SpecSchedule{DowLast: 1<<5 | 1<<0}},
+		{"MON,FRIL", SpecSchedule{Dow: 1 << 1, DowLast: 1 << 5}},
+
+		// Sunday as 7
+		{"7", SpecSchedule{Dow: 1 << 0}},
+		{"0,7", SpecSchedule{Dow: 1 << 0}},
+		{"5-7", SpecSchedule{Dow: 1<<5 | 1<<6 | 1<<0}},
+		{"FRI-7", SpecSchedule{Dow: 1<<5 | 1<<6 | 1<<0}},
+		{"1-7/2", SpecSchedule{Dow: 1<<1 | 1<<3 | 1<<5 | 1<<0}},
+		{"0-7", SpecSchedule{Dow: all(dow) &^ starBit}},
+		{"1/2", SpecSchedule{Dow: 1<<1 | 1<<3 | 1<<5}},
+		{"*/2", SpecSchedule{Dow: 1<<0 | 1<<2 | 1<<4 | 1<<6 | starBit}},
+		{"7#2", SpecSchedule{DowNth: 1 << 7}},
+		{"7L", SpecSchedule{DowLast: 1 << 0}},
+	}
+
+	for _, c := range fields {
+		var actual SpecSchedule
+		if err := (&Parser{}).getDowField(c.expr, &actual); err != nil {
+			t.Errorf("%s => (expected) nil != %s (actual)", c.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+}
+
+func TestDowFieldErrors(t *testing.T) {
+	// A bare L is ambiguous in the day of week field, so it is rejected.
+	var s SpecSchedule
+	if err := (&Parser{}).getDowField("L", &s); err == nil {
+		t.Error("expected an error parsing a bare L in the day of week field")
+	}
+
+	// W belongs to the day of month field only.
+	for _, field := range []string{"1W", "LW", "lw", "MON,15W"} {
+		for _, p := range []*Parser{{}, {quartz: true}} {
+			err := p.getDowField(field, &s)
+			if err == nil || !strings.Contains(err.Error(), "nearest weekday (W) is only allowed in the day-of-month field") {
+				t.Errorf("%s (quartz %v) => %v, expected an error about W", field, p.quartz, err)
+			}
+		}
+	}
+}
+
+func TestYearField(t *testing.T) {
+	fields := []struct {
+		expr     string
+		expected []int
+	}{
+		{"*", nil},
+		{"2027", []int{2027}},
+		{"1970,2099", []int{1970, 2099}},
+		{"2025-2030/2", []int{2025, 2027, 2029}},
+		{"2096/2", []int{2096, 2098}},
+		{"*/50", []int{1970, 2020, 2070}},
+	}
+
+	for _, c := range fields {
+		actual, err := getYearField(c.expr)
+		if err != nil {
+			t.Errorf("%s => (expected) nil != %s (actual)", c.expr, err)
+			continue
+		}
+		var expected yearSet
+		for _, year := range c.expected {
+			expected.add(uint(year))
+		}
+		if actual != expected {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, expected, actual)
+		}
+	}
+}
+
 func TestParseSchedule(t *testing.T) {
 	tokyo, _ := time.LoadLocation("Asia/Tokyo")
 	entries := []struct {
@@ -117,6 +236,24 @@ func TestParseSchedule(t *testing.T) {
 		{"@midnight", midnight(time.Local)},
 		{"TZ=UTC  @midnight", midnight(time.UTC)},
 		{"TZ=Asia/Tokyo @midnight", midnight(tokyo)},
+		{"CRON_TZ=UTC 0 5 * * * *", every5min(time.UTC)},
+		{"CRON_TZ=Asia/Tokyo 5 * * * *", every5min(tokyo)},
+		{"CRON_TZ=UTC @daily", midnight(time.UTC)},
+		{"CRON_TZ=Asia/Tokyo  @midnight", midnight(tokyo)},
+		{`TZ="Asia/Tokyo" 0 5 * * * *`, every5min(tokyo)},
+		{"CRON_TZ='UTC' @daily", midnight(time.UTC)},
+		{"  TZ=UTC\t5 * * * *  ", every5min(time.UTC)},
+		{"0 5 * * * * *", every5min(time.Local)},
+		{"0 5 * * * * 2027", &SpecSchedule{
+			Second:   1 << 0,
+			Minute:   1 << 5,
+			Hour:     all(hours),
+			Dom:      all(dom),
+			Month:    all(months),
+			Dow:      all(dow),
+			Year:     yearSet{1 << (2027 - 1970)},
+			Location: time.Local,
+		}},
 	}
 
 	for _, c := range entries {
@@ -131,9 +268,902 @@ func TestParseSchedule(t *testing.T) {
 }
 
 func every5min(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{1 << 0, 1 << 5, all(hours), all(dom), all(months), all(dow), loc}
+	return &SpecSchedule{
+		Second:   1 << 0,
+		Minute:   1 << 5,
+		Hour:     all(hours),
+		Dom:      all(dom),
+		Month:    all(months),
+		Dow:      all(dow),
+		Location: loc,
+	}
 }
 
 func midnight(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{1, 1, 1, all(dom), all(months), all(dow), loc}
+	return &SpecSchedule{
+		Second:   1,
+		Minute:   1,
+		Hour:     1,
+		Dom:      all(dom),
+		Month:    all(months),
+		Dow:      all(dow),
+		Location: loc,
+	}
+}
+
+func TestParseQuartz(t *testing.T) {
+	entries := []struct {
+		quartz, standard string
+	}{
+		{"0 0 12 ? * 2", "0 0 12 * * MON"},
+		{"0 0 12 ? * MON", "0 0 12 * * MON"},
+		{"0 0 12 ? * 1,7", "0 0 12 * * SUN,SAT"},
+		{"0 0 12 ? * 2-6", "0 0 12 * * MON-FRI"},
+		{"0 0 12 ? * 1-7/2", "0 0 12 * * SUN,TUE,THU,SAT"},
+		{"0 0 12 ? * *", "0 0 12 * * *"},
+		{"0 0 12 ? * L", "0 0 12 * * SAT"},
+		{"0 0 12 ? * 6L", "0 0 12 * * FRIL"},
+		{"0 0 12 ? * 6#3", "0 0 12 * * FRI#3"},
+		{"0 0 12 1 * ?", "0 0 12 1 * *"},
+		{"0 0 12 L * ?", "0 0 12 L * *"},
+		{"0 0 12 * * ?", "0 0 12 * * *"},
+		{"0 0 12 1 1 ? 2027", "0 0 12 1 1 * 2027"},
+		{"TZ=UTC 0 0 12 1 * ?", "TZ=UTC 0 0 12 1 * *"},
+	}
+
+	for _, c := range entries {
+		actual, err := ParseQuartz(c.quartz)
+		if err != nil {
+			t.Errorf("%s => (expected) nil != %s (actual)", c.quartz, err)
+			continue
+		}
+		expected, err := Parse(c.standard)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.quartz, expected, actual)
+		}
+	}
+
+	invalid := []string{
+		"0 12 ? * 2",
+		"0 0 12 1 * 2",
+		"0 0 12 * * 2",
+		"0 0 12 ? * ?",
+		"0 0 12 1,? * ?",
+		"0 0 12 ? * 2,?",
+		"0 0 ? 1 * ?",
+		"0 0 12 ? * 0",
+		"0 0 12 ? * 8",
+		"0 0 12 ? * 0#1",
+		"@daily",
+	}
+	for _, spec := range invalid {
+		if _, err := ParseQuartz(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestHashField(t *testing.T) {
+	fields := []struct {
+		expr     string
+		r        bounds
+		key      uint64
+		expected string
+	}{
+		{"H", minutes, 0, "0"},
+		{"H", minutes, 61, "1"},
+		{"h", hours, 100, "4"},
+		{"H", dom, 28, "1"},
+		{"H", dom, 27, "28"},
+		{"H(1-31)", dom, 30, "31"},
+		{"H(0-29)", minutes, 45, "15"},
+		{"H(mon-fri)", dow, 7, "3"},
+		{"H/15", minutes, 22, "7-59/15"},
+		{"H(0-29)/10", minutes, 13, "3-29/10"},
+		{"H(0-2)/10", minutes, 9, "0-2/10"},
+		{"H,30", minutes, 5, "5,30"},
+		{"1-5", minutes, 5, "1-5"},
+	}
+
+	for _, c := range fields {
+		actual, err := hashField(c.expr, c.r, c.key)
+		if err != nil {
+			t.Errorf("%s => (expected) nil != %s (actual)", c.expr, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s => (expected) %s != %s (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	invalid := []string{"H(5)", "H(30-10)", "H(0-60)", "H(0-5", "H/0", "H/x", "Hx"}
+	for _, expr := range invalid {
+		if _, err := hashField(expr, minutes, 0); err == nil {
+			t.Error("expected an error parsing: ", expr)
+		}
+	}
+}
+
+func TestParseWithHash(t *testing.T) {
+	specs := []string{"H H * * *", "H/15 * * * *", "0 H(0-29) H(9-17) * * H(mon-fri)", "H H H H H H"}
+	for _, spec := range specs {
+		first, err := ParseWithHash(spec, "billing-rollup")
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		second, err := ParseWithHash(spec, "billing-rollup")
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("%s => %v != %v for the same seed", spec, first, second)
+		}
+	}
+
+	// Different seeds spread the same spec across the field.
+	seen := make(map[uint64]bool)
+	for _, seed := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		sched, err := ParseWithHash("H * * * *", seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		minute := sched.(*SpecSchedule).Minute
+		if minute&^getBits(0, 59, 1) != 0 || bits.OnesCount64(minute) != 1 {
+			t.Errorf("seed %s => unexpected minutes %b", seed, minute)
+		}
+		seen[minute] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected different seeds to choose different minutes")
+	}
+
+	if _, err := Parse("H * * * *"); err == nil {
+		t.Error("expected an error parsing H without a seed")
+	}
+	if _, err := Parse("0 0 12 * * THU"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParserOptions(t *testing.T) {
+	entries := []struct {
+		opts  []ParseOption
+		spec  string
+		valid bool
+	}{
+		{nil, "5 * * * *", true},
+		{nil, "0 5 * * * *", true},
+		{nil, "0 5 * * * * 2027", true},
+		{nil, "@every 5m", true},
+
+		{[]ParseOption{WithSeconds()}, "0 5 * * * *", true},
+		{[]ParseOption{WithSeconds()}, "0 5 * * * * 2027", true},
+		{[]ParseOption{WithSeconds()}, "5 * * * *", false},
+
+		{[]ParseOption{WithoutSeconds()}, "5 * * * *", true},
+		{[]ParseOption{WithoutSeconds()}, "5 * * * * 2027", true},
+		{[]ParseOption{WithoutSeconds(), WithoutYear()}, "5 * * * * 2027", false},
+		{[]ParseOption{WithoutSeconds()}, "0 5 * * * * 2027", false},
+
+		{[]ParseOption{WithYear()}, "5 * * * * 2027", true},
+		{[]ParseOption{WithYear()}, "0 5 * * * * 2027", true},
+		{[]ParseOption{WithYear()}, "5 * * * *", false},
+		{[]ParseOption{WithoutYear()}, "0 5 * * * * 2027", false},
+
+		{[]ParseOption{WithoutDescriptors()}, "@daily", false},
+		{[]ParseOption{WithoutDescriptors()}, "@every 5m", false},
+		{[]ParseOption{WithoutDescriptors()}, "5 * * * *", true},
+		{[]ParseOption{WithoutEvery()}, "@daily", true},
+		{[]ParseOption{WithoutEvery()}, "TZ=UTC @every 5m", false},
+	}
+
+	for _, c := range entries {
+		_, err := NewParser(c.opts...).Parse(c.spec)
+		if c.valid && err != nil {
+			t.Errorf("%s => (expected) nil != %s (actual)", c.spec, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s => expected an error", c.spec)
+		}
+	}
+}
+
+func TestParserFieldPositions(t *testing.T) {
+	entries := []struct {
+		opts     []ParseOption
+		spec     string
+		expected Schedule
+	}{
+		{[]ParseOption{WithSeconds()}, "0 5 * * * *", every5min(time.Local)},
+		{[]ParseOption{WithoutSeconds()}, "5 * * * *", every5min(time.Local)},
+		{[]ParseOption{WithYear()}, "5 * * * * *", every5min(time.Local)},
+		{[]ParseOption{WithoutSeconds(), WithYear()}, "TZ=UTC 5 * * * * *", every5min(time.UTC)},
+	}
+
+	for _, c := range entries {
+		actual, err := NewParser(c.opts...).Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	var zero Parser
+	actual, err := zero.Parse("0 5 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, every5min(time.Local)) {
+		t.Errorf("zero Parser => (expected) %v != %v (actual)", every5min(time.Local), actual)
+	}
+}
+
+func TestParseStandard(t *testing.T) {
+	valid := []struct {
+		spec     string
+		expected Schedule
+	}{
+		{"5 * * * *", every5min(time.Local)},
+		{"TZ=UTC 5 * * * *", every5min(time.UTC)},
+		{"CRON_TZ=UTC @midnight", midnight(time.UTC)},
+		{"@every 5m", ConstantDelaySchedule{5 * time.Minute}},
+	}
+	for _, c := range valid {
+		actual, err := ParseStandard(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	_, err := ParseStandard("*/10 * * * * *")
+	if err == nil {
+		t.Fatal("expected an error parsing a seconds field")
+	}
+	if !strings.Contains(err.Error(), "does not accept a seconds field") || !strings.Contains(err.Error(), `"*/10"`) {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	for _, spec := range []string{"* * * *", "0 0 0 * * * 2027"} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseErrorMessages(t *testing.T) {
+	entries := []struct {
+		spec, expected string
+	}{
+		{"0 0 99 * *", `failed to parse field 3 (day-of-month): end of range (99) above maximum (31): "99"`},
+		{"0 0 99 * * *", `failed to parse field 3 (hours): end of range (99) above maximum (23): "99"`},
+		{"0-1-2 * * * *", `failed to parse field 1 (minutes): too many hyphens: "0-1-2"`},
+		{"* * * * 13 * *", `failed to parse field 5 (month): end of range (13) above maximum (12): "13"`},
+		{"* * * * * 1#6", `failed to parse field 6 (day-of-week): occurrence of day of week (6) must be between 1 and 5: "1#6"`},
+		{"0 0 * * 1W", `failed to parse field 5 (day-of-week): nearest weekday (W) is only allowed in the day-of-month field: "1W"`},
+		{"0 0 * * LW", `failed to parse field 5 (day-of-week): nearest weekday (W) is only allowed in the day-of-month field: "LW"`},
+		{"0 0 0 * * * 1969", `failed to parse field 7 (year): beginning of range (1969) below minimum (1970): "1969"`},
+		{"H * * * *", `failed to parse field 1 (minutes): H is only supported by WithHash: "H"`},
+		{"* * * *", `expected 5 to 7 fields, found 4: "* * * *"`},
+		{"@bogus", `unrecognized descriptor: "@bogus"`},
+		{"@daily at 5pm", `descriptor "@daily" takes no arguments, got "at 5pm"`},
+		{"@Weekly 0", `descriptor "@Weekly" takes no arguments, got "0"`},
+		{"@dialy", `unrecognized descriptor "@dialy", did you mean @daily?`},
+		{"@hourlyy", `unrecognized descriptor "@hourlyy", did you mean @hourly?`},
+		{"@evry 5m", `unrecognized descriptor "@evry", did you mean @every?`},
+		{"@every", `expected @every <duration> [offset <duration>]: "@every"`},
+		{"@every 1h extra words", `expected offset after duration, got "extra words": "@every 1h extra words"`},
+		{"@every 1h offset", `missing duration after offset: "@every 1h offset"`},
+		{"@every 1h offset 5m later", `unexpected "later" after offset: "@every 1h offset 5m later"`},
+		{"@every 1x", `failed to parse duration "1x" in "@every 1x": time: unknown unit "x" in duration "1x"`},
+		{"@every 1h offset 5", `failed to parse offset "5" in "@every 1h offset 5": time: missing unit in duration "5"`},
+	}
+	for _, c := range entries {
+		_, err := Parse(c.spec)
+		if err == nil {
+			t.Errorf("%s => expected an error", c.spec)
+			continue
+		}
+		if err.Error() != c.expected {
+			t.Errorf("%s => (expected) %s != %s (actual)", c.spec, c.expected, err)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse("TZ=UTC 0 0 99 * *")
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a *ParseError, got %T", err)
+	}
+	if perr.Spec != "TZ=UTC 0 0 99 * *" || perr.Field != 3 || perr.FieldName != "day-of-month" {
+		t.Errorf("unexpected error fields: %+v", perr)
+	}
+	if perr.Cause == nil || errors.Unwrap(err) != perr.Cause {
+		t.Errorf("expected the error to unwrap to its cause, got %v", errors.Unwrap(err))
+	}
+
+	_, err = Parse("@every 5x")
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a *ParseError, got %T", err)
+	}
+	if perr.Spec != "@every 5x" || perr.Field != 0 {
+		t.Errorf("unexpected error fields: %+v", perr)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if !reflect.DeepEqual(MustParse("@midnight"), midnight(time.Local)) {
+		t.Error("unexpected schedule from MustParse")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustParse to panic")
+		} else if _, ok := r.(*ParseError); !ok {
+			t.Errorf("expected a *ParseError, got %T", r)
+		}
+	}()
+	MustParse("* * * *")
+}
+
+func TestValidate(t *testing.T) {
+	for _, spec := range []string{
+		"* * * * *",
+		"0 0 12 1 1 ? 2027",
+		"TZ=UTC 0 0 * * *",
+		"@every 5m",
+		"@reboot",
+	} {
+		if err := Validate(spec); err != nil {
+			t.Errorf("%s => unexpected error: %v", spec, err)
+		}
+	}
+
+	invalid := []struct {
+		spec     string
+		expected error
+	}{
+		{"*/0 * * * *", ErrZeroStep},
+		{"1-10/0 * * * *", ErrZeroStep},
+		{"0 0 0 * * * 2020/0", ErrZeroStep},
+		{"5/0 * * * *", ErrZeroStep},
+		{"*/ * * * *", ErrZeroStep},
+		{"5/ * * * *", ErrZeroStep},
+		{"/ * * * *", nil},
+		{"// * * * *", nil},
+		{"*// * * * *", nil},
+		{"*/-1 * * * *", nil},
+		{"*/1.5 * * * *", nil},
+		{"1,,3 * * * *", ErrEmptyListElement},
+		{"* * 1, * *", ErrEmptyListElement},
+		{"* * * * ,MON", ErrEmptyListElement},
+		{", * * * *", ErrEmptyListElement},
+		{"* * * * MON,", ErrEmptyListElement},
+		{"1, 5 * * * *", ErrEmptyListElement},
+		{"1 ,5 * * * *", ErrEmptyListElement},
+		{"TZ=Bogus/Zone * * * * *", nil},
+		{"TZ=UTC", nil},
+		{"TZ=UTC   ", nil},
+		{"TZ= * * * * *", nil},
+		{"TZ=", nil},
+		{`TZ="" * * * * *`, nil},
+		{`TZ="UTC * * * * *`, nil},
+		{"@bogus", nil},
+	}
+	for _, c := range invalid {
+		err := Validate(c.spec)
+		if err == nil {
+			t.Errorf("%s => expected an error", c.spec)
+			continue
+		}
+		if c.expected != nil && !errors.Is(err, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, err)
+		}
+		if _, perr := Parse(c.spec); perr == nil || perr.Error() != err.Error() {
+			t.Errorf("%s => Parse returned %v, Validate returned %v", c.spec, perr, err)
+		}
+	}
+
+	if err := ValidateStandard("0 * * * * *"); err == nil {
+		t.Error("expected ValidateStandard to reject a seconds field")
+	}
+	if err := ValidateStandard("0 * * * *"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNamedSteps(t *testing.T) {
+	monthExprs := []struct {
+		named, numeric string
+	}{
+		{"JAN/3", "1/3"},
+		{"jan/3", "1/3"},
+		{"Feb/2", "2/2"},
+		{"JAN-DEC/3", "1-12/3"},
+		{"mar-Sep/2", "3-9/2"},
+		{"JAN-JUN/2,OCT", "1-6/2,10"},
+	}
+	for _, c := range monthExprs {
+		named, err := getField(c.named, months)
+		if err != nil {
+			t.Errorf("%s => %s", c.named, err)
+			continue
+		}
+		numeric, _ := getField(c.numeric, months)
+		if named != numeric {
+			t.Errorf("%s => (expected) %b != %b (actual)", c.named, numeric, named)
+		}
+	}
+
+	dowExprs := []struct {
+		named, numeric string
+		quartz         bool
+	}{
+		{"MON/2", "1/2", false},
+		{"mon-FRI/2", "1-5/2", false},
+		{"SUN-SAT/3", "0-6/3", false},
+		{"Tue/2,sun", "2/2,0", false},
+		{"MON/2", "2/2", true},
+		{"sun-Sat/3", "1-7/3", true},
+	}
+	for _, c := range dowExprs {
+		var named, numeric SpecSchedule
+		if err := (&Parser{quartz: c.quartz}).getDowField(c.named, &named); err != nil {
+			t.Errorf("%s => %s", c.named, err)
+			continue
+		}
+		(&Parser{quartz: c.quartz}).getDowField(c.numeric, &numeric)
+		if named != numeric {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.named, numeric, named)
+		}
+	}
+}
+
+func TestWrappedRanges(t *testing.T) {
+	entries := []struct {
+		spec     string
+		expected *SpecSchedule
+	}{
+		{"0 0 22-2 * * *", &SpecSchedule{
+			Second: 1 << 0, Minute: 1 << 0, Hour: 1<<22 | 1<<23 | 1<<0 | 1<<1 | 1<<2,
+			Dom: all(dom), Month: all(months), Dow: all(dow), Location: time.Local,
+		}},
+		{"50-10/5 * * * *", &SpecSchedule{
+			Second: 1 << 0, Minute: 1<<50 | 1<<55 | 1<<0 | 1<<5 | 1<<10,
+			Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow), Location: time.Local,
+		}},
+		{"0 0 28-2 NOV-FEB FRI-MON/2", &SpecSchedule{
+			Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0,
+			Dom:   1<<28 | 1<<29 | 1<<30 | 1<<31 | 1<<1 | 1<<2,
+			Month: 1<<11 | 1<<12 | 1<<1 | 1<<2,
+			Dow:   1<<5 | 1<<0, Location: time.Local,
+		}},
+		{"0 0 * * SAT-7", &SpecSchedule{
+			Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0,
+			Dom: all(dom), Month: all(months), Dow: 1<<6 | 1<<0, Location: time.Local,
+		}},
+		{"0 0 * * 6-1", &SpecSchedule{
+			Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0,
+			Dom: all(dom), Month: all(months), Dow: 1<<6 | 1<<0 | 1<<1, Location: time.Local,
+		}},
+	}
+	for _, c := range entries {
+		actual, err := Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	actual, err := ParseQuartz("0 0 0 ? * FRI-MON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dow := actual.(*SpecSchedule).Dow; dow != 1<<5|1<<6|1<<0|1<<1 {
+		t.Errorf("quartz FRI-MON => %b", dow)
+	}
+
+	strict := NewParser(WithStrictRanges())
+	for _, spec := range []string{"0 0 22-2 * * *", "0 0 * * FRI-MON", "0 0 28-2 * *", "0 0 1 NOV-FEB *"} {
+		if _, err := strict.Parse(spec); err == nil || !strings.Contains(err.Error(), "beyond end of range") {
+			t.Errorf("%s => expected a strict range error, got %v", spec, err)
+		}
+	}
+	if _, err := Parse("0 0 0 * * * 2030-2020"); err == nil {
+		t.Error("expected an error for a reversed year range")
+	}
+}
+
+func TestNameErrors(t *testing.T) {
+	days := "SUN, MON, TUE, WED, THU, FRI, SAT"
+	entries := []struct {
+		spec, expected string
+	}{
+		{"* * * * TUES", `failed to parse field 5 (day-of-week): unrecognized name "TUES", expected a number or one of ` + days + `; did you mean TUE?`},
+		{"* * * * MON-THUR", `failed to parse field 5 (day-of-week): unrecognized name "THUR", expected a number or one of ` + days + `; did you mean THU?`},
+		{"* * * * Monday", `failed to parse field 5 (day-of-week): unrecognized name "Monday", expected a number or one of ` + days + `; did you mean MON?`},
+		{"* * * * FRIL2", `failed to parse field 5 (day-of-week): failed to parse int from "FRIL2": strconv.Atoi: parsing "FRIL2": invalid syntax`},
+		{"* * * * XYZ", `failed to parse field 5 (day-of-week): unrecognized name "XYZ", expected a number or one of ` + days},
+		{"* * * * WEDS#2", `failed to parse field 5 (day-of-week): unrecognized name "WEDS", expected a number or one of ` + days + `; did you mean WED?`},
+		{"* * * SEPT *", `failed to parse field 4 (month): unrecognized name "SEPT", expected a number or one of JAN, FEB, MAR, APR, MAY, JUN, JUL, AUG, SEP, OCT, NOV, DEC; did you mean SEP?`},
+		{"* * * JANUARY *", `failed to parse field 4 (month): unrecognized name "JANUARY", expected a number or one of JAN, FEB, MAR, APR, MAY, JUN, JUL, AUG, SEP, OCT, NOV, DEC; did you mean JAN?`},
+		{"* * * Monday *", `failed to parse field 4 (month): unrecognized name "Monday", expected a number or one of JAN, FEB, MAR, APR, MAY, JUN, JUL, AUG, SEP, OCT, NOV, DEC`},
+		{"* * MON * *", `failed to parse field 3 (day-of-month): failed to parse int from "MON": strconv.Atoi: parsing "MON": invalid syntax`},
+	}
+	for _, c := range entries {
+		_, err := Parse(c.spec)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("%s => expected %q, got %v", c.spec, c.expected, err)
+		}
+	}
+}
+
+func TestWithLenientNames(t *testing.T) {
+	lenient := NewParser(WithLenientNames())
+	entries := []struct {
+		lenient, strict string
+	}{
+		{"0 9 * * Tues", "0 9 * * TUE"},
+		{"0 9 * * Monday-Friday", "0 9 * * MON-FRI"},
+		{"0 9 * * THUR,thurs,Thursday", "0 9 * * THU"},
+		{"0 9 * Sept,September *", "0 9 * SEP *"},
+		{"0 9 * January-March *", "0 9 * JAN-MAR *"},
+		{"0 9 * * FridayL", "0 9 * * FRIL"},
+		{"0 9 * * Tuesday#2", "0 9 * * TUE#2"},
+	}
+	for _, c := range entries {
+		actual, err := lenient.Parse(c.lenient)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.lenient, err)
+			continue
+		}
+		expected := MustParse(c.strict)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.lenient, expected, actual)
+		}
+		if err := Validate(c.lenient); err == nil {
+			t.Errorf("%s => expected an error without WithLenientNames", c.lenient)
+		}
+	}
+
+	actual, err := NewParser(WithLenientNames(), WithQuartz()).Parse("0 0 9 ? * Sunday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dow := actual.(*SpecSchedule).Dow; dow != 1<<time.Sunday {
+		t.Errorf("quartz Sunday => %b", dow)
+	}
+
+	_, err = lenient.Parse("* * * * Thursdy")
+	if err == nil || !strings.HasSuffix(err.Error(), "did you mean THURSDAY?") {
+		t.Errorf("Thursdy => unexpected error: %v", err)
+	}
+}
+
+func TestDescriptors(t *testing.T) {
+	for _, c := range [][2]string{
+		{"@MIDNIGHT", "@midnight"},
+		{"@Daily", "@daily"},
+		{"@EVERY 5m", "@every 5m"},
+		{"TZ=UTC @Hourly", "TZ=UTC @hourly"},
+		{"@minutely", "0 * * * * *"},
+		{"@weekday", "0 0 0 * * MON-FRI"},
+		{"@Weekend", "0 0 0 * * SAT,SUN"},
+		{"TZ=UTC @weekend", "TZ=UTC 0 0 0 * * 0,6"},
+	} {
+		expected, err := Parse(c[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := Parse(c[0])
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c[0], err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c[0], expected, actual)
+		}
+	}
+
+	if _, err := Parse("@every 5M"); err == nil {
+		t.Error("expected the duration to remain case sensitive")
+	}
+	if _, err := NewParser(WithoutEvery()).Parse("@Every 5m"); err == nil {
+		t.Error("expected WithoutEvery to reject @Every")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	entries := []struct {
+		expr     string
+		expected time.Duration
+	}{
+		{"5m", 5 * time.Minute},
+		{"2d", 48 * time.Hour},
+		{"1w", 168 * time.Hour},
+		{"1w2d12h", 228 * time.Hour},
+		{"1d30m", 24*time.Hour + 30*time.Minute},
+		{"1.5d", 36 * time.Hour},
+		{"-1d", -24 * time.Hour},
+		{"1h1d", 25 * time.Hour},
+	}
+	for _, c := range entries {
+		actual, err := parseDuration(c.expr)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.expr, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	for _, expr := range []string{"1dd", "d", "1d5", "1.2.3d", "1wd", "1x2d"} {
+		if _, err := parseDuration(expr); err == nil {
+			t.Errorf("%s => expected an error", expr)
+		}
+	}
+
+	actual, err := Parse("@every 2d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, ConstantDelaySchedule{48 * time.Hour}) {
+		t.Errorf("@every 2d => %v", actual)
+	}
+}
+
+func TestParseEveryOffset(t *testing.T) {
+	entries := []struct {
+		spec     string
+		expected Schedule
+	}{
+		{"@every 1h offset 15m", AlignedDelaySchedule{time.Hour, 15 * time.Minute}},
+		{"@every 1d OFFSET 2h30m", AlignedDelaySchedule{24 * time.Hour, 150 * time.Minute}},
+		{"@every 5m offset 0s", AlignedDelaySchedule{5 * time.Minute, 0}},
+	}
+	for _, c := range entries {
+		actual, err := Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	for _, spec := range []string{"@every 1h offset", "@every 1h offset 15", "@every 1h after 15m", "@every 1h 15m"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%s => expected an error", spec)
+		}
+	}
+}
+
+func TestWithLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(WithLocation(time.UTC))
+	entries := []struct {
+		spec     string
+		expected *time.Location
+	}{
+		{"0 9 * * *", time.UTC},
+		{"@daily", time.UTC},
+		{"TZ=Asia/Tokyo 0 9 * * *", tokyo},
+		{"CRON_TZ=Asia/Tokyo @weekly", tokyo},
+	}
+	for _, c := range entries {
+		sched, err := p.Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if loc := sched.(*SpecSchedule).Location; loc.String() != c.expected.String() {
+			t.Errorf("%s => location %v, expected %v", c.spec, loc, c.expected)
+		}
+	}
+
+	sched, _ := p.Parse("0 9 * * *")
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, tokyo)
+	if next := sched.Next(from); !next.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("next = %v, expected 09:00 UTC on Mar 1", next)
+	}
+}
+
+func TestWithUnsatisfiableCheck(t *testing.T) {
+	p := NewParser(WithUnsatisfiableCheck())
+	for _, spec := range []string{
+		"0 0 0 29 2 *",
+		"0 0 0 31 1-2 *",
+		"0 0 0 30 2 5",
+		"0 0 0 29 2 * 2024",
+		"@daily",
+	} {
+		if _, err := p.Parse(spec); err != nil {
+			t.Errorf("%s => unexpected error: %v", spec, err)
+		}
+	}
+
+	invalid := []struct {
+		spec, expected string
+	}{
+		{"0 0 0 31 2 *", `spec can never activate: day-of-month and month never coincide: "0 0 0 31 2 *"`},
+		{"0 0 0 30,31 2 *", "day-of-month and month never coincide"},
+		{"0 0 0 31 4,6,9,11 *", "day-of-month and month never coincide"},
+		{"0 0 0 29 2 * 2021-2023", "day-of-month and year never coincide"},
+		{"0 0 0 31W 2 *", "day-of-month and month never coincide"},
+	}
+	for _, c := range invalid {
+		_, err := p.Parse(c.spec)
+		if !errors.Is(err, ErrUnsatisfiable) || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("%s => %v, expected an error containing %q", c.spec, err, c.expected)
+		}
+	}
+
+	// Without the option, unsatisfiable specs still parse.
+	if _, err := Parse("0 0 0 31 2 *"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOversizedSteps(t *testing.T) {
+	entries := []struct {
+		spec, expected string
+	}{
+		{"0 */100 * * * *", "0 0 * * * *"},
+		{"0 5/100 * * * *", "0 5 * * * *"},
+		{"0 1-10/100 * * * *", "0 1 * * * *"},
+		{"0 0 0 * * * */500", "0 0 0 * * * 1970"},
+	}
+	for _, c := range entries {
+		actual, err := Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if !Equal(actual, MustParse(c.expected)) {
+			t.Errorf("%s => %v, expected %s", c.spec, actual, c.expected)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, spec := range []string{
+		"* * * * *", "*/0 * * * *", "/ * * * *", "// * * * *", "1-/2 * * * *",
+		"0 0 0 30 2 *", "0 0 0 LW * 5L", "0 0 0 ? * MON#5 2099", "@every 1h offset 15m",
+		"TZ=UTC 0 9 * * *", "22-2 * * * *", "1,,5 * * * *",
+	} {
+		f.Add(spec)
+	}
+	from := time.Date(2012, 7, 9, 0, 0, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, spec string) {
+		sched, err := Parse(spec)
+		if err != nil {
+			return
+		}
+		sched.Next(from)
+		sched.(PrevSchedule).Prev(from)
+	})
+}
+
+func TestListErrorMessages(t *testing.T) {
+	entries := []struct {
+		spec, expected string
+	}{
+		{"1,,5 * * * *", `failed to parse field 1 (minutes): empty element in list: "1,,5"`},
+		{"* * * * MON,", `failed to parse field 5 (day-of-week): empty element in list: "MON,"`},
+		{"* * , * *", `failed to parse field 3 (day-of-month): empty element in list: ","`},
+		{"1, 5 * * * *", `empty element in list, as lists may not contain spaces: "1, 5"`},
+	}
+	for _, c := range entries {
+		_, err := Parse(c.spec)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("%s => %v, expected %s", c.spec, err, c.expected)
+		}
+	}
+	if _, err := getField("", minutes); err == nil || err.Error() != "empty field" {
+		t.Errorf("empty field => %v", err)
+	}
+}
+
+func TestLocationPrefixErrors(t *testing.T) {
+	entries := []struct {
+		spec, expected string
+	}{
+		{"TZ=UTC", `missing schedule after location: "TZ=UTC"`},
+		{"TZ= * * * * *", `missing location after TZ=: "TZ= * * * * *"`},
+		{`CRON_TZ="" @daily`, `missing location after CRON_TZ=: "CRON_TZ=\"\" @daily"`},
+		{`TZ="UTC * * * * *`, `unbalanced quotes in location: "TZ=\"UTC * * * * *"`},
+	}
+	for _, c := range entries {
+		_, err := Parse(c.spec)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("%s => expected %q, got %v", c.spec, c.expected, err)
+		}
+	}
+}
+
+func TestPolicyPrefixes(t *testing.T) {
+	entries := []struct {
+		parser   *Parser
+		spec     string
+		expected DomDowPolicy
+	}{
+		{NewParser(), "0 0 13 * FRI", DomOrDow},
+		{NewParser(), "DOMDOW=AND 0 0 13 * FRI", DomAndDow},
+		{NewParser(), "DOMDOW=and 0 0 13 * FRI", DomAndDow},
+		{NewParser(), "TZ=UTC DOMDOW=AND @daily", DomAndDow},
+		{NewParser(WithDomDowPolicy(DomAndDow)), "0 0 13 * FRI", DomAndDow},
+		{NewParser(WithDomDowPolicy(DomAndDow)), "DOMDOW=OR TZ=UTC 0 0 13 * FRI", DomOrDow},
+	}
+	for _, c := range entries {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error: %v", c.spec, err)
+			continue
+		}
+		if actual := sched.(*SpecSchedule).DomDow; actual != c.expected {
+			t.Errorf("%s => DomDow (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	invalid := []struct {
+		spec, expected string
+	}{
+		{"DOMDOW=XOR 0 0 13 * FRI", `unknown DOMDOW policy "XOR", expected AND or OR`},
+		{"DOMDOW= 0 0 13 * FRI", `missing value after DOMDOW=: "DOMDOW= 0 0 13 * FRI"`},
+		{"DOMDOW=AND", `missing schedule after DOMDOW=AND: "DOMDOW=AND"`},
+		{"TZ=UTC DOMDOW=AND", `missing schedule after DOMDOW=AND: "DOMDOW=AND"`},
+		{"GAP=RUN 0 30 2 * * *", `unknown GAP policy "RUN", expected AFTER or SKIP`},
+		{"GAP=SKIP", `missing schedule after GAP=SKIP: "GAP=SKIP"`},
+		{"REPEAT=ALL 0 30 1 * * *", `unknown REPEAT policy "ALL", expected FIRST or BOTH`},
+		{"REPEAT= 0 30 1 * * *", `missing value after REPEAT=: "REPEAT= 0 30 1 * * *"`},
+	}
+	for _, c := range invalid {
+		_, err := Parse(c.spec)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("%s => expected %q, got %v", c.spec, c.expected, err)
+		}
+	}
+}
+
+func FuzzLocationPrefix(f *testing.F) {
+	for _, spec := range []string{
+		"TZ=UTC", "TZ=", "TZ= ", "CRON_TZ=UTC @daily", `TZ="Asia/Tokyo" * * * * *`,
+		`TZ="`, `TZ='UTC'`, " TZ=UTC\t* * * * * ", "TZ=UTC\n",
+	} {
+		f.Add(spec)
+	}
+	f.Fuzz(func(t *testing.T, spec string) {
+		loc, rest, err := parseLocationPrefix(spec, time.UTC)
+		if err != nil {
+			return
+		}
+		if loc == nil {
+			t.Errorf("%q => nil location", spec)
+		}
+		if rest != strings.TrimSpace(rest) {
+			t.Errorf("%q => untrimmed rest %q", spec, rest)
+		}
+		Validate(spec)
+	})
 }
@@ -1,6 +1,8 @@
 package cron
 
 import (
+	"math"
+	"math/bits"
 	"time"
 )
 
@@ -8,30 +10,91 @@ import (
 type Schedule interface {
 	// Next returns the next activation time, later than the given time.
 	Next(time.Time) time.Time
-	// Next returns the next activation time, later than the given time.
+	// Previous returns the previous activation time, earlier than the given
+	// time.
 	Previous(time.Time) time.Time
 }
 
+// PrevSchedule is a Schedule whose Prev method returns its previous activation
+// time, strictly earlier than the given time, as every schedule in this
+// package does.  Schedules that wrap others, such as Union, call Prev on those
+// that implement it and Previous on the rest.
+type PrevSchedule interface {
+	Schedule
+	// Prev returns the previous activation time, strictly earlier than the
+	// given time.
+	Prev(time.Time) time.Time
+}
+
+// previous returns the previous activation time of the schedule, using Prev if
+// it is a PrevSchedule.
+func previous(s Schedule, t time.Time) time.Time {
+	if p, ok := s.(PrevSchedule); ok {
+		return p.Prev(t)
+	}
+	return s.Previous(t)
+}
+
 // SpecSchedule specifies a duty cycle (to the second granularity), based on a
 // traditional crontab specification. It is computed initially and stored as bit sets.
 type SpecSchedule struct {
 	Second, Minute, Hour, Dom, Month, Dow uint64
-	Location                              *time.Location
+
+	// DomLast is set if the schedule activates on the last day of each month.
+	DomLast bool
+
+	// DomWeekday holds the days of the month whose nearest weekday activates
+	// the schedule, and DomLastWeekday is set if the last weekday of each month
+	// does.
+	DomWeekday     uint64
+	DomLastWeekday bool
+
+	// DowNth holds the nth occurrences of each day of the week within the month
+	// that activate the schedule, as bit (n-1)*7 + weekday.
+	DowNth uint64
+
+	// DowLast holds the days of the week whose last occurrence within the month
+	// activates the schedule.
+	DowLast uint64
+
+	// DomDow selects whether a day must satisfy the day-of-month, the
+	// day-of-week or both when both are restricted.
+	DomDow DomDowPolicy
+
+	// Year restricts the schedule to the given years.  The zero value matches
+	// every year.
+	Year yearSet
+
+	// Gap selects what happens to activations at wall clock times that are
+	// skipped by a daylight savings transition.
+	Gap GapPolicy
+
+	// Repeat selects what happens to activations at wall clock times that
+	// occur twice because of a daylight savings transition.
+	Repeat RepeatPolicy
+
+	Location *time.Location
 }
 
-// bounds provides a range of acceptable values (plus a map of name to value).
+// bounds provides a range of acceptable values (plus a map of name to value),
+// along with the name of the field they apply to.  If wrap is set, a range may
+// wrap around from the maximum to the minimum, e.g. "22-2".  If lenient is set,
+// the names may also be given in any of their forms in nameAliases.
 type bounds struct {
+	field    string
 	min, max uint
 	names    map[string]uint
+	wrap     bool
+	lenient  bool
 }
 
 // The bounds for each field.
 var (
-	seconds = bounds{0, 59, nil}
-	minutes = bounds{0, 59, nil}
-	hours   = bounds{0, 23, nil}
-	dom     = bounds{1, 31, nil}
-	months  = bounds{1, 12, map[string]uint{
+	seconds = bounds{field: "seconds", min: 0, max: 59}
+	minutes = bounds{field: "minutes", min: 0, max: 59}
+	hours   = bounds{field: "hours", min: 0, max: 23}
+	dom     = bounds{field: "day-of-month", min: 1, max: 31}
+	months  = bounds{field: "month", min: 1, max: 12, names: map[string]uint{
 		"jan": 1,
 		"feb": 2,
 		"mar": 3,
@@ -45,7 +108,7 @@ var (
 		"nov": 11,
 		"dec": 12,
 	}}
-	dow = bounds{0, 6, map[string]uint{
+	dow = bounds{field: "day-of-week", min: 0, max: 6, names: map[string]uint{
 		"sun": 0,
 		"mon": 1,
 		"tue": 2,
@@ -54,23 +117,163 @@ var (
 		"fri": 5,
 		"sat": 6,
 	}}
+	years = bounds{field: "year", min: 1970, max: 2099}
+
+	// dowWithSeven extends the day of week bounds to allow 7 for Sunday.
+	dowWithSeven = bounds{field: dow.field, min: 0, max: 7, names: dow.names}
+
+	// quartzDow numbers the days of the week the way Quartz does.
+	quartzDow = bounds{field: dow.field, min: 1, max: 7, names: map[string]uint{
+		"sun": 1,
+		"mon": 2,
+		"tue": 3,
+		"wed": 4,
+		"thu": 5,
+		"fri": 6,
+		"sat": 7,
+	}}
 )
 
+// nameAliases maps the longer forms of month and day-of-week names that
+// WithLenientNames accepts to their three-letter forms.
+var nameAliases = map[string]string{
+	"january":   "jan",
+	"february":  "feb",
+	"march":     "mar",
+	"april":     "apr",
+	"june":      "jun",
+	"july":      "jul",
+	"august":    "aug",
+	"sept":      "sep",
+	"september": "sep",
+	"october":   "oct",
+	"november":  "nov",
+	"december":  "dec",
+	"sunday":    "sun",
+	"monday":    "mon",
+	"tues":      "tue",
+	"tuesday":   "tue",
+	"wednesday": "wed",
+	"thur":      "thu",
+	"thurs":     "thu",
+	"thursday":  "thu",
+	"friday":    "fri",
+	"saturday":  "sat",
+}
+
+// fieldBounds lists the bounds of each field in the order they appear in a
+// spec, starting with the seconds.
+var fieldBounds = []bounds{seconds, minutes, hours, dom, months, dow, years}
+
 const (
 	// Set the top bit if a star was included in the expression.
 	starBit = 1 << 63
 )
 
+// DomDowPolicy selects how a SpecSchedule combines its day-of-month and
+// day-of-week restrictions.
+type DomDowPolicy int
+
+const (
+	// DomOrDow matches days that satisfy either restriction, as classic cron
+	// does, so that "0 0 13 * FRI" activates on the 13th and on every Friday.
+	// A field given as "*" or "?" does not restrict the days, in which case
+	// only the other one applies.  This is the default.
+	DomOrDow DomDowPolicy = iota
+
+	// DomAndDow matches only days that satisfy both restrictions, so that
+	// "0 0 13 * FRI" activates on Friday the 13th.
+	DomAndDow
+)
+
+// yearSet is a bit set of years, offset from years.min.  Years are kept apart
+// from the other fields because they do not fit in a single uint64.
+type yearSet [3]uint64
+
+// add sets the bit for the given year, which must be within the year bounds.
+func (y *yearSet) add(year uint) {
+	i := year - years.min
+	y[i/64] |= 1 << (i % 64)
+}
+
+// isZero returns true if no year has been added, meaning every year matches.
+func (y yearSet) isZero() bool {
+	return y == yearSet{}
+}
+
+// contains returns true if the given year is matched by the set.
+func (y yearSet) contains(year int) bool {
+	if y.isZero() {
+		return true
+	}
+	if year < int(years.min) || year > int(years.max) {
+		return false
+	}
+	i := uint(year) - years.min
+	return y[i/64]&(1<<(i%64)) > 0
+}
+
+// next returns the earliest matching year at or after the given one.
+func (y yearSet) next(year int) (int, bool) {
+	if y.isZero() {
+		return year, true
+	}
+	if year < int(years.min) {
+		year = int(years.min)
+	}
+	for ; year <= int(years.max); year++ {
+		if y.contains(year) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// prev returns the latest matching year at or before the given one.
+func (y yearSet) prev(year int) (int, bool) {
+	if y.isZero() {
+		return year, true
+	}
+	if year > int(years.max) {
+		year = int(years.max)
+	}
+	for ; year >= int(years.min); year-- {
+		if y.contains(year) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Next(t time.Time) time.Time {
+	next := s.next(t)
+	if s.Repeat == RunFirstRepeat {
+		for i := 0; i < zoneTransitionLimit; i++ {
+			_, end, ok := s.repeated(next)
+			if !ok {
+				break
+			}
+			next = s.next(end.Add(-time.Nanosecond))
+		}
+	}
+	if s.Gap == RunAfterGap {
+		next = s.nextAfterGap(t, next)
+	}
+	return next
+}
+
+// next returns the next activation at a wall clock time that exists in the
+// schedule's location.
+func (s *SpecSchedule) next(t time.Time) time.Time {
 	// General approach:
-	// For Month, Day, Hour, Minute, Second:
-	// Check if the time value matches.  If yes, continue to the next field.
-	// If the field doesn't match the schedule, then increment the field until it matches.
-	// While incrementing the field, a wrap-around brings it back to the beginning
-	// of the field list (since it is necessary to re-verify previous field
-	// values)
+	// Between two zone transitions, the schedule's location has a fixed
+	// offset, so wall clock times map to instants one to one and in order.
+	// Find the earliest matching wall clock time at or after t's within the
+	// zone containing t.  If it lies beyond the zone's end, the wall clock
+	// times in between were either skipped or will repeat, so search again
+	// from the start of the following zone.
 
 	// Convert the given time into the schedule's timezone.
 	// Save the original timezone so we can convert back after we find a time.
@@ -80,190 +283,328 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
 	// Start at the earliest possible time (the upcoming second).
 	t = t.Add(1*time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
 
-	// This flag indicates whether a field has been incremented.
-	added := false
-
-	// If no time is found within five years, return zero.
-	yearLimit := t.Year() + 5
+	for i := 0; i < zoneTransitionLimit; i++ {
+		_, offset := t.Zone()
+		_, end := t.ZoneBounds()
+		wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		match, ok := s.nextWall(wall)
+		if !ok {
+			return time.Time{}
+		}
+		next := match.Add(-time.Duration(offset) * time.Second)
+		if end.IsZero() || next.Before(end) {
+			return next.In(origLocation)
+		}
+		t = end
+	}
+	return time.Time{}
+}
 
-WRAP:
-	if t.Year() > yearLimit {
-		return time.Time{}
+// nextWall returns the earliest wall clock time at or after w, given in UTC,
+// that the schedule matches.  Each field jumps straight to its next matching
+// value, carrying into the field above when it has none.  It returns false if
+// there is no such time, which is certain once a whole 400 year cycle of the
+// Gregorian calendar has been searched, or once the schedule's last year has.
+func (s *SpecSchedule) nextWall(w time.Time) (time.Time, bool) {
+	limit := w.Year() + 400
+	if last, ok := s.Year.prev(int(years.max)); ok && !s.Year.isZero() {
+		limit = last
 	}
 
-	// Find the first applicable month.
-	// If it's this month, then do nothing.
-	for 1<<uint(t.Month())&s.Month == 0 {
-		// If we have to add a month, reset the other parts to 0.
-		if !added {
-			added = true
-			// Otherwise, set the date at the beginning (since the current time is irrelevant).
-			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.Location)
+	year, month, day := w.Date()
+	hour, minute, second := w.Clock()
+	for year <= limit {
+		if !s.Year.contains(year) {
+			next, ok := s.Year.next(year)
+			if !ok {
+				return time.Time{}, false
+			}
+			year, month, day, hour, minute, second = next, time.January, 1, 0, 0, 0
 		}
-		t = t.AddDate(0, 1, 0)
 
-		// Wrapped around.
-		if t.Month() == time.January {
-			goto WRAP
+		m, ok := nextBit(s.Month, uint(month))
+		if !ok {
+			year, month, day, hour, minute, second = year+1, time.January, 1, 0, 0, 0
+			continue
+		}
+		if time.Month(m) != month {
+			month, day, hour, minute, second = time.Month(m), 1, 0, 0, 0
 		}
-	}
 
-	// Now get a day in that month.
-	for !dayMatches(s, t) {
-		if !added {
-			added = true
-			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.Location)
+		d, ok := nextBit(s.dayMask(year, month), uint(day))
+		if !ok {
+			month, day, hour, minute, second = month+1, 1, 0, 0, 0
+			continue
+		}
+		if int(d) != day {
+			day, hour, minute, second = int(d), 0, 0, 0
 		}
-		t = t.AddDate(0, 0, 1)
 
-		if t.Day() == 1 {
-			goto WRAP
+		h, ok := nextBit(s.Hour, uint(hour))
+		if !ok {
+			day, hour, minute, second = day+1, 0, 0, 0
+			continue
+		}
+		if int(h) != hour {
+			hour, minute, second = int(h), 0, 0
 		}
-	}
 
-	for 1<<uint(t.Hour())&s.Hour == 0 {
-		if !added {
-			added = true
-			t = t.Truncate(time.Hour)
+		mi, ok := nextBit(s.Minute, uint(minute))
+		if !ok {
+			hour, minute, second = hour+1, 0, 0
+			continue
+		}
+		if int(mi) != minute {
+			minute, second = int(mi), 0
 		}
-		t = t.Add(1 * time.Hour)
 
-		if t.Hour() == 0 {
-			goto WRAP
+		sec, ok := nextBit(s.Second, uint(second))
+		if !ok {
+			minute, second = minute+1, 0
+			continue
 		}
+		return time.Date(year, month, day, hour, minute, int(sec), 0, time.UTC), true
 	}
+	return time.Time{}, false
+}
 
-	for 1<<uint(t.Minute())&s.Minute == 0 {
-		if !added {
-			added = true
-			t = t.Truncate(time.Minute)
-		}
-		t = t.Add(1 * time.Minute)
+// nextBit returns the lowest bit set in b at or above from, ignoring the star
+// bit, or false if there is none.
+func nextBit(b uint64, from uint) (uint, bool) {
+	if from >= 64 {
+		return 0, false
+	}
+	b &^= starBit
+	b &= math.MaxUint64 << from
+	if b == 0 {
+		return 0, false
+	}
+	return uint(bits.TrailingZeros64(b)), true
+}
 
-		if t.Minute() == 0 {
-			goto WRAP
+// dayMask returns the days of the given month that satisfy the schedule's
+// day-of-month and day-of-week restrictions, as a bit for each day.  It
+// mirrors dayMatches.
+func (s *SpecSchedule) dayMask(year int, month time.Month) uint64 {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := daysInMonth(year, month)
+	days := getBits(1, uint(last), 1)
+
+	domMask := s.Dom & days
+	if s.DomLast {
+		domMask |= 1 << uint(last)
+	}
+	if s.DomLastWeekday {
+		domMask |= 1 << uint(nearestWeekday(first, last))
+	}
+	for w := s.DomWeekday; w != 0; w &= w - 1 {
+		if day := nearestWeekday(first, bits.TrailingZeros64(w)); day > 0 {
+			domMask |= 1 << uint(day)
 		}
 	}
 
-	for 1<<uint(t.Second())&s.Second == 0 {
-		if !added {
-			added = true
-			t = t.Truncate(time.Second)
+	var dowMask uint64
+	if s.Dow&^starBit == getBits(dow.min, dow.max, 1) {
+		dowMask = days
+	} else {
+		// Lay each week of the month out in turn, from the first's weekday.
+		firstWeekday := int(first.Weekday())
+		for day := 1; day <= last; day++ {
+			weekday := (firstWeekday + day - 1) % 7
+			if 1<<uint(weekday)&s.Dow > 0 ||
+				1<<uint((day-1)/7*7+weekday)&s.DowNth > 0 ||
+				1<<uint(weekday)&s.DowLast > 0 && day+7 > last {
+				dowMask |= 1 << uint(day)
+			}
 		}
-		t = t.Add(1 * time.Second)
+	}
 
-		if t.Second() == 0 {
-			goto WRAP
-		}
+	if s.daysAnd() {
+		return domMask & dowMask
 	}
+	return domMask | dowMask
+}
 
-	return t.In(origLocation)
+// Prev returns the previous time this schedule was activated, strictly less
+// than the given time.  If no time can be found to satisfy the schedule, return
+// the zero time.
+func (s *SpecSchedule) Prev(t time.Time) time.Time {
+	prev := s.prev(t)
+	if s.Repeat == RunFirstRepeat {
+		for i := 0; i < zoneTransitionLimit; i++ {
+			start, _, ok := s.repeated(prev)
+			if !ok {
+				break
+			}
+			prev = s.prev(start)
+		}
+	}
+	if s.Gap == RunAfterGap {
+		prev = s.prevAfterGap(t, prev)
+	}
+	return prev
 }
 
-// Previous returns the previous time this schedule is activated, greater than the given
-// time.  If no time can be found to satisfy the schedule, return the zero time.
-func (s *SpecSchedule) Previous(t time.Time) time.Time {
+// prev returns the previous activation at a wall clock time that existed in
+// the schedule's location.
+func (s *SpecSchedule) prev(t time.Time) time.Time {
 	// General approach:
-	// For Month, Day, Hour, Minute, Second:
-	// Check if the time value matches.  If yes, continue to the next field.
-	// If the field doesn't match the schedule, then increment the field until it matches.
-	// While incrementing the field, a wrap-around brings it back to the beginning
-	// of the field list (since it is necessary to re-verify previous field
-	// values)
+	// As in next, but backwards: find the latest matching wall clock time at
+	// or before t's within the zone containing t.  If it lies before the
+	// zone's start, search again from the end of the preceding zone.
 
 	// Convert the given time into the schedule's timezone.
 	// Save the original timezone so we can convert back after we find a time.
 	origLocation := t.Location()
 	t = t.In(s.Location)
 
-	// Start at the earliest possible time (the upcoming second).
-	t = t.Add(-1*time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
-
-	// This flag indicates whether a field has been incremented.
-	added := false
-
-	// If no time is found within five years, return zero.
-	yearLimit := t.Year() - 5
-	changedMonth := false
-
-WRAP:
-	if t.Year() < yearLimit {
-		return time.Time{}
+	// Start at the latest possible time (the preceding second).
+	if t.Nanosecond() > 0 {
+		t = t.Add(-time.Duration(t.Nanosecond()) * time.Nanosecond)
+	} else {
+		t = t.Add(-1 * time.Second)
 	}
 
-	// Find the first applicable month.
-	// If it's this month, then do nothing.
-	for 1<<uint(t.Month())&s.Month == 0 {
-		// If we have to subtract a month, reset the other parts to 0.
-		if !added {
-			added = true
-			// Otherwise, set the date at the end (since the current time is irrelevant).
-			t = time.Date(t.Year(), t.Month(), daysInMonth(t.Year(), t.Month()), 23, 59, 59, 0, s.Location)
+	for i := 0; i < zoneTransitionLimit; i++ {
+		_, offset := t.Zone()
+		start, _ := t.ZoneBounds()
+		wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		match, ok := s.prevWall(wall)
+		if !ok {
+			return time.Time{}
 		}
-		t = t.AddDate(0, -1, 0)
-		changedMonth = true
-
-		// Wrapped around.
-		if t.Month() == time.December {
-			goto WRAP
+		prev := match.Add(-time.Duration(offset) * time.Second)
+		if start.IsZero() || !prev.Before(start) {
+			return prev.In(origLocation)
 		}
+		t = start.Add(-1 * time.Second)
 	}
+	return time.Time{}
+}
 
-	if changedMonth {
-		changedMonth = false
-		t = time.Date(t.Year(), t.Month(), daysInMonth(t.Year(), t.Month()), 23, 59, 59, 0, s.Location)
+// prevWall returns the latest wall clock time at or before w, given in UTC,
+// that the schedule matches.  It mirrors nextWall, each field jumping straight
+// to its previous matching value and borrowing from the field above when it has
+// none.  It returns false if there is no such time, which is certain once a
+// whole 400 year cycle of the Gregorian calendar has been searched, or once the
+// schedule's first year has.
+func (s *SpecSchedule) prevWall(w time.Time) (time.Time, bool) {
+	limit := w.Year() - 400
+	if first, ok := s.Year.next(int(years.min)); ok && !s.Year.isZero() {
+		limit = first
 	}
 
-	// Now get a day in that month.
-	for !dayMatches(s, t) {
-		if !added {
-			added = true
-			t = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, s.Location)
+	// Days are started from the 31st, whatever the month, as dayMask has no
+	// bits beyond the month's last day.
+	year, month, day := w.Date()
+	hour, minute, second := w.Clock()
+	for year >= limit {
+		if !s.Year.contains(year) {
+			prev, ok := s.Year.prev(year)
+			if !ok {
+				return time.Time{}, false
+			}
+			year, month, day, hour, minute, second = prev, time.December, 31, 23, 59, 59
 		}
-		t = t.AddDate(0, 0, -1)
 
-		if lastDayInMonth(t) {
-			goto WRAP
+		m, ok := prevBit(s.Month, int(month))
+		if !ok {
+			year, month, day, hour, minute, second = year-1, time.December, 31, 23, 59, 59
+			continue
+		}
+		if time.Month(m) != month {
+			month, day, hour, minute, second = time.Month(m), 31, 23, 59, 59
 		}
-	}
 
-	for 1<<uint(t.Hour())&s.Hour == 0 {
-		if !added {
-			added = true
-			t = t.Truncate(time.Hour)
+		d, ok := prevBit(s.dayMask(year, month), day)
+		if !ok {
+			month, day, hour, minute, second = month-1, 31, 23, 59, 59
+			continue
+		}
+		if int(d) != day {
+			day, hour, minute, second = int(d), 23, 59, 59
 		}
-		t = t.Add(-1 * time.Hour)
 
-		if t.Hour() == 23 {
-			goto WRAP
+		h, ok := prevBit(s.Hour, hour)
+		if !ok {
+			day, hour, minute, second = day-1, 23, 59, 59
+			continue
+		}
+		if int(h) != hour {
+			hour, minute, second = int(h), 59, 59
 		}
-	}
 
-	for 1<<uint(t.Minute())&s.Minute == 0 {
-		if !added {
-			added = true
-			t = t.Truncate(time.Minute)
+		mi, ok := prevBit(s.Minute, minute)
+		if !ok {
+			hour, minute, second = hour-1, 59, 59
+			continue
+		}
+		if int(mi) != minute {
+			minute, second = int(mi), 59
 		}
-		t = t.Add(-1 * time.Minute)
 
-		if t.Minute() == 59 {
-			goto WRAP
+		sec, ok := prevBit(s.Second, second)
+		if !ok {
+			minute, second = minute-1, 59
+			continue
 		}
+		return time.Date(year, month, day, hour, minute, int(sec), 0, time.UTC), true
 	}
+	return time.Time{}, false
+}
 
-	for 1<<uint(t.Second())&s.Second == 0 {
-		if !added {
-			added = true
-			t = t.Truncate(time.Second)
-		}
-		t = t.Add(-1 * time.Second)
+// prevBit returns the highest bit set in b at or below from, ignoring the star
+// bit, or false if there is none.
+func prevBit(b uint64, from int) (uint, bool) {
+	if from < 0 {
+		return 0, false
+	}
+	b &^= starBit
+	if from < 63 {
+		b &= 1<<uint(from+1) - 1
+	}
+	if b == 0 {
+		return 0, false
+	}
+	return uint(bits.Len64(b) - 1), true
+}
 
-		if t.Second() == 59 {
-			goto WRAP
-		}
+// Previous is the same as Prev, for the Schedule interface.
+func (s *SpecSchedule) Previous(t time.Time) time.Time {
+	return s.Prev(t)
+}
+
+// Matches returns true if the schedule activates at the second containing the
+// given time.
+func (s *SpecSchedule) Matches(t time.Time) bool {
+	t = t.Truncate(time.Second).In(s.Location)
+	return s.Year.contains(t.Year()) &&
+		1<<uint(t.Month())&s.Month > 0 &&
+		dayMatches(s, t) &&
+		1<<uint(t.Hour())&s.Hour > 0 &&
+		1<<uint(t.Minute())&s.Minute > 0 &&
+		1<<uint(t.Second())&s.Second > 0
+}
+
+// Satisfiable returns true if the schedule ever activates, e.g. false for
+// February 30th.  It considers wall clock times only, so a schedule whose only
+// activations are skipped by daylight savings transitions is still
+// satisfiable.
+func Satisfiable(s *SpecSchedule) bool {
+	first, ok := s.Year.next(int(years.min))
+	if !ok {
+		return false
 	}
+	_, ok = s.nextWall(time.Date(first, time.January, 1, 0, 0, 0, 0, time.UTC))
+	return ok
+}
 
-	return t.In(origLocation)
+// WithLocation returns a copy of the schedule that activates at the same wall
+// clock times in loc instead.  The schedule itself is left unchanged.
+func (s *SpecSchedule) WithLocation(loc *time.Location) *SpecSchedule {
+	c := *s
+	c.Location = loc
+	return &c
 }
 
 func lastDayInMonth(t time.Time) bool {
@@ -271,19 +612,75 @@ func lastDayInMonth(t time.Time) bool {
 }
 
 func daysInMonth(year int, month time.Month) int {
-	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, -1).Day()
+	switch month {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// nearestWeekday returns the weekday closest to the given day in t's month,
+// without leaving the month.  It returns 0 if the month has no such day.
+func nearestWeekday(t time.Time, day int) int {
+	last := daysInMonth(t.Year(), t.Month())
+	if day > last {
+		return 0
+	}
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// weekdayMatches returns true if t falls on the nearest weekday of one of the
+// schedule's W days.
+func weekdayMatches(s *SpecSchedule, t time.Time) bool {
+	if s.DomLastWeekday && t.Day() == nearestWeekday(t, daysInMonth(t.Year(), t.Month())) {
+		return true
+	}
+	for day := dom.min; day <= dom.max; day++ {
+		if 1<<day&s.DomWeekday > 0 && t.Day() == nearestWeekday(t, int(day)) {
+			return true
+		}
+	}
+	return false
 }
 
 // dayMatches returns true if the schedule's day-of-week and day-of-month
 // restrictions are satisfied by the given time.
 func dayMatches(s *SpecSchedule, t time.Time) bool {
 	var (
-		domMatch bool = 1<<uint(t.Day())&s.Dom > 0
-		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0
+		domMatch bool = 1<<uint(t.Day())&s.Dom > 0 ||
+			s.DomLast && lastDayInMonth(t) ||
+			weekdayMatches(s, t)
+		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0 ||
+			1<<uint((t.Day()-1)/7*7+int(t.Weekday()))&s.DowNth > 0 ||
+			1<<uint(t.Weekday())&s.DowLast > 0 && t.Day()+7 > daysInMonth(t.Year(), t.Month())
 	)
 
-	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
+	if s.daysAnd() {
 		return domMatch && dowMatch
 	}
 	return domMatch || dowMatch
 }
+
+// daysAnd returns true if a day must satisfy both the day-of-month and the
+// day-of-week, either because of the schedule's DomDow policy or because one
+// of them has a star and so matches every day.
+func (s *SpecSchedule) daysAnd() bool {
+	return s.DomDow == DomAndDow || s.Dom&starBit > 0 || s.Dow&starBit > 0
+}
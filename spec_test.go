@@ -53,6 +53,11 @@ func TestActivation(t *testing.T) {
 		{"Mon Jul 9 00:00 2012", "0 * * 1,15 * *", false},
 		{"Sun Jul 15 00:00 2012", "0 * * 1,15 * *", true},
 		{"Sun Jul 15 00:00 2012", "0 * * */2 * Sun", true},
+
+		// Last day of the month, ORed with a restricted day of week.
+		{"Tue Jul 31 00:00 2012", "0 0 L * Mon", true},
+		{"Mon Jul 30 00:00 2012", "0 0 L * Mon", true},
+		{"Sun Jul 29 00:00 2012", "0 0 L * Mon", false},
 	}
 
 	for _, test := range tests {
@@ -67,92 +72,287 @@ func TestActivation(t *testing.T) {
 			t.Errorf("Fail evaluating %s on %s: (expected) %s != %s (actual)",
 				test.spec, test.time, expected, actual)
 		}
+		if matches := sched.(*SpecSchedule).Matches(expected); matches != test.expected {
+			t.Errorf("Fail matching %s on %s: (expected) %v != %v (actual)",
+				test.spec, test.time, test.expected, matches)
+		}
 	}
 }
 
-func TestNext(t *testing.T) {
-	runs := []struct {
+func TestMatches(t *testing.T) {
+	tests := []struct {
 		time, spec string
-		expected   string
+		expected   bool
 	}{
-		// Simple cases
-		{"Mon Jul 9 14:45 2012", "0/15 * * * *", "Mon Jul 9 15:00 2012"},
-		{"Mon Jul 9 14:59 2012", "0/15 * * * *", "Mon Jul 9 15:00 2012"},
-		{"Mon Jul 9 14:59:59 2012", "0/15 * * * *", "Mon Jul 9 15:00 2012"},
-
-		// Wrap around hours
-		{"Mon Jul 9 15:45 2012", "20-35/15 * * * *", "Mon Jul 9 16:20 2012"},
-
-		// Wrap around days
-		{"Mon Jul 9 23:46 2012", "*/15 * * * *", "Tue Jul 10 00:00 2012"},
-		{"Mon Jul 9 23:45 2012", "20-35/15 * * * *", "Tue Jul 10 00:20 2012"},
-		{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 * * * *", "Tue Jul 10 00:20:15 2012"},
-		{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 1/2 * * *", "Tue Jul 10 01:20:15 2012"},
-		{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 10-12 * * *", "Tue Jul 10 10:20:15 2012"},
-
-		{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 1/2 */2 * *", "Thu Jul 11 01:20:15 2012"},
-		{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 * 9-20 * *", "Wed Jul 10 00:20:15 2012"},
-		{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 * 9-20 Jul *", "Wed Jul 10 00:20:15 2012"},
-
-		// Wrap around months
-		{"Mon Jul 9 23:35 2012", "0 0 0 9 Apr-Oct ?", "Thu Aug 9 00:00 2012"},
-		{"Mon Jul 9 23:35 2012", "0 0 0 */5 Apr,Aug,Oct Mon", "Mon Aug 6 00:00 2012"},
-		{"Mon Jul 9 23:35 2012", "0 0 0 */5 Oct Mon", "Mon Oct 1 00:00 2012"},
-
-		// Wrap around years
-		{"Mon Jul 9 23:35 2012", "0 0 0 * Feb Mon", "Mon Feb 4 00:00 2013"},
-		{"Mon Jul 9 23:35 2012", "0 0 0 * Feb Mon/2", "Fri Feb 1 00:00 2013"},
+		// Times within the second match.
+		{"Mon Jul 9 15:00:00.5 2012", "0 0 * * * *", true},
+		{"Mon Jul 9 15:00:01 2012", "0 0 * * * *", false},
 
-		// Wrap around minute, hour, day, month, and year
-		{"Mon Dec 31 23:59:45 2012", "0 * * * * *", "Tue Jan 1 00:00:00 2013"},
+		// Times are converted to the schedule's location.
+		{"2012-07-09T15:00:00+0000", "TZ=Asia/Tokyo 0 0 0 * * *", true},
+		{"2012-07-09T15:00:00+0000", "TZ=UTC 0 0 0 * * *", false},
 
-		// Leap year
-		{"Mon Jul 9 23:35 2012", "0 0 0 29 Feb ?", "Mon Feb 29 00:00 2016"},
-
-		// Daylight savings time 2am EST (-5) -> 3am EDT (-4)
-		{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 11 Mar ?", "2013-03-11T02:30:00-0400"},
-
-		// hourly job
-		{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T01:00:00-0500"},
-		{"2012-03-11T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T03:00:00-0400"},
-		{"2012-03-11T03:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T04:00:00-0400"},
-		{"2012-03-11T04:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T05:00:00-0400"},
-
-		// 1am nightly job
-		{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-03-11T01:00:00-0500"},
-		{"2012-03-11T01:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-03-12T01:00:00-0400"},
-
-		// 2am nightly job (skipped)
-		{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-03-12T02:00:00-0400"},
-
-		// Daylight savings time 2am EDT (-4) => 1am EST (-5)
-		{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 30 2 04 Nov ?", "2012-11-04T02:30:00-0500"},
-		{"2012-11-04T01:45:00-0400", "TZ=America/New_York 0 30 1 04 Nov ?", "2012-11-04T01:30:00-0500"},
+		// Years
+		{"Tue Jan 1 00:00 2013", "0 0 0 1 1 ? 2013", true},
+		{"Wed Jan 1 00:00 2014", "0 0 0 1 1 ? 2013", false},
+	}
 
-		// hourly job
-		{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0400"},
-		{"2012-11-04T01:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0500"},
-		{"2012-11-04T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T02:00:00-0500"},
+	for _, test := range tests {
+		sched := MustParse(test.spec).(*SpecSchedule)
+		if matches := sched.Matches(getTime(test.time)); matches != test.expected {
+			t.Errorf("Fail matching %s on %s: (expected) %v != %v (actual)",
+				test.spec, test.time, test.expected, matches)
+		}
+	}
+}
 
-		// 1am nightly job (runs twice)
-		{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 1 * * ?", "2012-11-04T01:00:00-0400"},
-		{"2012-11-04T01:00:00-0400", "TZ=America/New_York 0 0 1 * * ?", "2012-11-04T01:00:00-0500"},
-		{"2012-11-04T01:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-11-05T01:00:00-0500"},
+func TestSatisfiable(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected bool
+	}{
+		{"* * * * * *", true},
+		{"0 0 0 29 Feb ?", true},
+		{"0 0 0 30 Feb ?", false},
+		{"0 0 0 31 Apr,Jun ?", false},
+		{"0 0 0 31 Apr,Jul ?", true},
+		{"0 0 0 30 Feb FRI", true},
+		{"0 0 0 ? Feb MON#5", true},
+		{"0 0 0 L Feb ?", true},
+		{"0 0 0 29 Feb ? 2097-2099", false},
+		{"0 0 0 29 Feb ? 2096-2099", true},
+	}
+	for _, c := range tests {
+		if actual := Satisfiable(MustParse(c.spec).(*SpecSchedule)); actual != c.expected {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+}
 
-		// 2am nightly job
-		{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 2 * * ?", "2012-11-04T02:00:00-0500"},
-		{"2012-11-04T02:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-11-05T02:00:00-0500"},
+func TestDomDowPolicy(t *testing.T) {
+	tests := []struct {
+		spec, from string
+		or, and    string
+		prev       bool
+	}{
+		{"0 0 0 13 * FRI", "Sat Jul 14 00:00 2012", "Fri Jul 20 00:00 2012", "Fri Sep 13 00:00 2013", false},
+		{"0 0 0 13 * FRI", "Thu Jul 12 00:00 2012", "Fri Jul 6 00:00 2012", "Fri Apr 13 00:00 2012", true},
+		{"0 0 0 1 * MON", "Tue Jul 31 00:00 2012", "Wed Aug 1 00:00 2012", "Mon Oct 1 00:00 2012", false},
+		{"0 0 0 1 * MON", "Tue Oct 2 00:00 2012", "Mon Oct 1 00:00 2012", "Mon Oct 1 00:00 2012", true},
+		{"0 0 0 L * SUN", "Mon Jul 2 00:00 2012", "Sun Jul 8 00:00 2012", "Sun Sep 30 00:00 2012", false},
+
+		// A star in either field makes both policies the same.
+		{"0 0 0 * * FRI", "Sat Jul 14 00:00 2012", "Fri Jul 20 00:00 2012", "Fri Jul 20 00:00 2012", false},
+		{"0 0 0 13 * ?", "Sat Jul 14 00:00 2012", "Mon Aug 13 00:00 2012", "Mon Aug 13 00:00 2012", false},
+	}
+	for _, c := range tests {
+		or := MustParse(c.spec).(*SpecSchedule)
+		and, err := NewParser(WithDomDowPolicy(DomAndDow)).Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range []struct {
+			sched    Schedule
+			expected string
+		}{{or, c.or}, {and, c.and}} {
+			actual := p.sched.Next(getTime(c.from))
+			if c.prev {
+				actual = p.sched.(PrevSchedule).Prev(getTime(c.from))
+			}
+			if !actual.Equal(getTime(p.expected)) {
+				t.Errorf("%s from %s (policy %v): (expected) %s != %v (actual)",
+					c.spec, c.from, p.sched.(*SpecSchedule).DomDow, p.expected, actual)
+			}
+			if !p.sched.(*SpecSchedule).Matches(getTime(p.expected)) {
+				t.Errorf("%s (policy %v) does not match %s", c.spec, p.sched.(*SpecSchedule).DomDow, p.expected)
+			}
+		}
+	}
+}
 
-		// 3am nightly job
-		{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 3 * * ?", "2012-11-04T03:00:00-0500"},
-		{"2012-11-04T03:00:00-0500", "TZ=America/New_York 0 0 3 * * ?", "2012-11-05T03:00:00-0500"},
+func TestSpecWithLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := MustParse("0 0 9 * * *").(*SpecSchedule)
+	rehomed := local.WithLocation(tokyo)
+	if local.Location != time.Local {
+		t.Errorf("original location changed to %v", local.Location)
+	}
+	if rehomed.Location != tokyo {
+		t.Errorf("location %v, expected %v", rehomed.Location, tokyo)
+	}
 
-		// Unsatisfiable
-		{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
-		{"Mon Jul 9 23:35 2012", "0 0 0 31 Apr ?", ""},
+	from := time.Date(2012, 7, 9, 0, 0, 0, 0, time.UTC)
+	expected := time.Date(2012, 7, 10, 9, 0, 0, 0, tokyo)
+	if next := rehomed.Next(from); !next.Equal(expected) {
+		t.Errorf("next = %v, expected %v", next, expected)
 	}
+	if !Equal(rehomed, MustParse("TZ=Asia/Tokyo 0 0 9 * * *")) {
+		t.Errorf("%v differs from the same spec parsed in Tokyo", rehomed)
+	}
+}
 
-	for _, c := range runs {
+// nextRuns are the fixtures of TestNext: the expected activation after each time.
+var nextRuns = []struct {
+	time, spec string
+	expected   string
+}{
+	// Simple cases
+	{"Mon Jul 9 14:45 2012", "0/15 * * * *", "Mon Jul 9 15:00 2012"},
+	{"Mon Jul 9 14:59 2012", "0/15 * * * *", "Mon Jul 9 15:00 2012"},
+	{"Mon Jul 9 14:59:59 2012", "0/15 * * * *", "Mon Jul 9 15:00 2012"},
+
+	// Wrap around hours
+	{"Mon Jul 9 15:45 2012", "20-35/15 * * * *", "Mon Jul 9 16:20 2012"},
+
+	// Wrap around days
+	{"Mon Jul 9 23:46 2012", "*/15 * * * *", "Tue Jul 10 00:00 2012"},
+	{"Mon Jul 9 23:45 2012", "20-35/15 * * * *", "Tue Jul 10 00:20 2012"},
+	{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 * * * *", "Tue Jul 10 00:20:15 2012"},
+	{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 1/2 * * *", "Tue Jul 10 01:20:15 2012"},
+	{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 10-12 * * *", "Tue Jul 10 10:20:15 2012"},
+
+	{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 1/2 */2 * *", "Thu Jul 11 01:20:15 2012"},
+	{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 * 9-20 * *", "Wed Jul 10 00:20:15 2012"},
+	{"Mon Jul 9 23:35:51 2012", "15/35 20-35/15 * 9-20 Jul *", "Wed Jul 10 00:20:15 2012"},
+
+	// Wrap around months
+	{"Mon Jul 9 23:35 2012", "0 0 0 9 Apr-Oct ?", "Thu Aug 9 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 */5 Apr,Aug,Oct Mon", "Mon Aug 6 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 */5 Oct Mon", "Mon Oct 1 00:00 2012"},
+
+	// Wrap around years
+	{"Mon Jul 9 23:35 2012", "0 0 0 * Feb Mon", "Mon Feb 4 00:00 2013"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 * Feb Mon/2", "Fri Feb 1 00:00 2013"},
+
+	// Wrap around minute, hour, day, month, and year
+	{"Mon Dec 31 23:59:45 2012", "0 * * * * *", "Tue Jan 1 00:00:00 2013"},
+
+	// Leap year
+	{"Mon Jul 9 23:35 2012", "0 0 0 29 Feb ?", "Mon Feb 29 00:00 2016"},
+	{"2096-03-01T00:00:00+0000", "TZ=UTC 0 0 0 29 Feb ?", "2104-02-29T00:00:00+0000"},
+	{"2012-07-09T00:00:00+0000", "TZ=UTC 0 0 0 13 * FRI", "2012-07-13T00:00:00+0000"},
+	{"2012-07-09T00:00:00+0000", "TZ=UTC 0 0 12 29 Feb ? *", "2016-02-29T12:00:00+0000"},
+
+	// Sparse schedules far in the future
+	{"2012-07-09T00:00:00+0000", "TZ=UTC 0 0 0 1 1 ? 2099", "2099-01-01T00:00:00+0000"},
+	{"2012-07-09T00:00:00+0000", "TZ=UTC 0 0 0 29 Feb MON#5", "2016-02-29T00:00:00+0000"},
+
+	// Daylight savings time 2am EST (-5) -> 3am EDT (-4): skipped times
+	// activate when the gap ends.
+	{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 11 Mar ?", "2012-03-11T03:00:00-0400"},
+
+	// hourly job
+	{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T01:00:00-0500"},
+	{"2012-03-11T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T03:00:00-0400"},
+	{"2012-03-11T03:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T04:00:00-0400"},
+	{"2012-03-11T04:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T05:00:00-0400"},
+
+	// 1am nightly job
+	{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-03-11T01:00:00-0500"},
+	{"2012-03-11T01:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-03-12T01:00:00-0400"},
+
+	// Zones with a fractional offset
+	{"2024-01-01T07:10:00+0530", "TZ=Asia/Kolkata 0 0 9 * * *", "2024-01-01T09:00:00+0530"},
+
+	// 2am nightly job (run after the gap)
+	{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-03-11T03:00:00-0400"},
+	{"2012-03-11T03:00:00-0400", "TZ=America/New_York 0 0 2 * * ?", "2012-03-12T02:00:00-0400"},
+
+	// Daylight savings time 2am EDT (-4) => 1am EST (-5)
+	{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 30 2 04 Nov ?", "2012-11-04T02:30:00-0500"},
+	{"2012-11-04T01:45:00-0400", "TZ=America/New_York 0 30 1 04 Nov ?", "2013-11-04T01:30:00-0500"},
+
+	// hourly job
+	{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0400"},
+	{"2012-11-04T01:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0500"},
+	{"2012-11-04T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T02:00:00-0500"},
+
+	// 1am nightly job (runs once, at the first occurrence)
+	{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 1 * * ?", "2012-11-04T01:00:00-0400"},
+	{"2012-11-04T01:00:00-0400", "TZ=America/New_York 0 0 1 * * ?", "2012-11-05T01:00:00-0500"},
+
+	// 2am nightly job
+	{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 2 * * ?", "2012-11-04T02:00:00-0500"},
+	{"2012-11-04T02:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-11-05T02:00:00-0500"},
+
+	// 3am nightly job
+	{"2012-11-04T00:00:00-0400", "TZ=America/New_York 0 0 3 * * ?", "2012-11-04T03:00:00-0500"},
+	{"2012-11-04T03:00:00-0500", "TZ=America/New_York 0 0 3 * * ?", "2012-11-05T03:00:00-0500"},
+
+	// Last day of the month
+	{"Tue Nov 15 12:00 2011", "0 0 0 L * *", "Wed Nov 30 00:00 2011"},
+	{"Wed Nov 30 00:00 2011", "0 0 0 L * *", "Sat Dec 31 00:00 2011"},
+	{"Sat Dec 31 00:00 2011", "0 0 0 L * *", "Tue Jan 31 00:00 2012"},
+	{"Tue Jan 31 00:00 2012", "0 0 0 L * *", "Wed Feb 29 00:00 2012"},
+	{"Wed Feb 29 00:00 2012", "0 0 0 L Feb *", "Thu Feb 28 00:00 2013"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 15,L * *", "Sun Jul 15 00:00 2012"},
+	{"Sun Jul 15 00:00 2012", "0 0 0 15,L * *", "Tue Jul 31 00:00 2012"},
+	{"Tue Jul 31 00:00 2012", "0 0 0 15,L * *", "Wed Aug 15 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 l Apr,Jun ?", "Fri Apr 30 00:00 2013"},
+
+	// Nearest weekday
+	{"Mon Jul 9 23:35 2012", "0 0 0 11W * *", "Wed Jul 11 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 14W * *", "Fri Jul 13 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 15W * *", "Mon Jul 16 00:00 2012"},
+	{"Mon Jul 16 00:00 2012", "0 0 0 15W * *", "Wed Aug 15 00:00 2012"},
+	{"Wed Aug 15 00:00 2012", "0 0 0 1W * *", "Mon Sep 3 00:00 2012"},
+	{"Mon Sep 3 00:00 2012", "0 0 0 30W * *", "Fri Sep 28 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 31W Sep-Oct *", "Wed Oct 31 00:00 2012"},
+	{"Mon Sep 3 00:00 2012", "0 0 0 LW * *", "Fri Sep 28 00:00 2012"},
+	{"Fri Sep 28 00:00 2012", "0 0 0 LW * *", "Wed Oct 31 00:00 2012"},
+	{"Wed Oct 31 00:00 2012", "0 0 0 lw * *", "Fri Nov 30 00:00 2012"},
+	{"Fri Nov 30 00:00 2012", "0 0 0 LW * *", "Mon Dec 31 00:00 2012"},
+	{"Wed Aug 15 00:00 2012", "0 0 0 1W,15 * *", "Mon Sep 3 00:00 2012"},
+
+	// Nth day of the week
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * MON#2", "Mon Aug 13 09:00 2012"},
+	{"Mon Jul 9 08:00 2012", "0 0 9 ? * MON#2", "Mon Jul 9 09:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * 5#5", "Fri Aug 31 09:00 2012"},
+	{"Fri Aug 31 09:00 2012", "0 0 9 ? * FRI#5", "Fri Nov 30 09:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * MON,FRI#1", "Mon Jul 16 09:00 2012"},
+	{"Mon Jul 30 23:35 2012", "0 0 9 ? * TUE,FRI#1", "Tue Jul 31 09:00 2012"},
+	{"Tue Jul 31 09:00 2012", "0 0 9 ? * WED,FRI#1", "Wed Aug 1 09:00 2012"},
+	{"Wed Aug 1 09:00 2012", "0 0 9 ? * Sun#1,Fri#1", "Fri Aug 3 09:00 2012"},
+	{"Fri Aug 3 09:00 2012", "0 0 9 ? * Sun#1,Fri#1", "Sun Aug 5 09:00 2012"},
+
+	// Last day of the week in the month
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * FRIL", "Fri Jul 27 09:00 2012"},
+	{"Fri Jul 27 09:00 2012", "0 0 9 ? * 5L", "Fri Aug 31 09:00 2012"},
+	{"Fri Aug 31 09:00 2012", "0 0 9 ? * friL", "Fri Sep 28 09:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * MON,TUEL", "Mon Jul 16 09:00 2012"},
+	{"Mon Jul 30 09:00 2012", "0 0 9 ? * 0L,TUEL", "Tue Jul 31 09:00 2012"},
+	{"Tue Jul 31 09:00 2012", "0 0 9 ? * 0L,TUEL", "Sun Aug 26 09:00 2012"},
+	{"Sun Dec 30 23:35 2012", "0 0 9 ? Feb SUNL", "Sun Feb 24 09:00 2013"},
+	{"Sun Dec 30 23:35 2012", "0 0 9 ? Feb 7L", "Sun Feb 24 09:00 2013"},
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * 7#1", "Sun Aug 5 09:00 2012"},
+
+	// Sunday as 7
+	{"Mon Jul 9 23:35 2012", "0 0 0 ? * 7", "Sun Jul 15 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 ? * 5-7", "Fri Jul 13 00:00 2012"},
+	{"Sat Jul 14 00:00 2012", "0 0 0 ? * 5-7", "Sun Jul 15 00:00 2012"},
+
+	// Year field
+	{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 2027", "Fri Jan 1 12:00 2027"},
+	{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 2025-2030/2", "Wed Jan 1 12:00 2025"},
+	{"Wed Jan 1 12:00 2025", "0 0 12 1 1 ? 2025-2030/2", "Fri Jan 1 12:00 2027"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 29 Feb ? 2012-2099", "Fri Feb 29 00:00 2016"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 * * * 2012", "Tue Jul 10 00:00 2012"},
+	{"Mon Dec 31 23:59:59 2012", "* * * * * * 2012,2014", "Wed Jan 1 00:00:00 2014"},
+	{"Wed Feb 29 00:00 2096", "0 0 0 29 Feb ?", "Fri Feb 29 00:00 2104"},
+
+	// Unsatisfiable
+	{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
+	{"Mon Jul 9 23:35 2012", "0 0 0 31 Apr ?", ""},
+	{"Mon Jul 9 23:35 2012", "0 0 0 * * * 2011", ""},
+	{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ? 2012-2099", ""},
+	{"Mon Dec 31 23:59:59 2099", "* * * * * * 2099", ""},
+}
+
+func TestNext(t *testing.T) {
+	for _, c := range nextRuns {
 		sched, err := Parse(c.spec)
 		if err != nil {
 			t.Error(err)
@@ -166,40 +366,123 @@ func TestNext(t *testing.T) {
 	}
 }
 
-func TestPrevious(t *testing.T) {
-	runs := []struct {
-		time, spec string
-		expected   string
-	}{
-		// Simple cases
-		{"Mon Jul 9 14:45 2012", "0/15 * * * *", "Mon Jul 9 14:30 2012"},
-		{"Mon Jul 9 14:59 2012", "0/15 * * * *", "Mon Jul 9 14:45 2012"},
-		{"Mon Jul 9 14:59:59 2012", "0/15 * * * *", "Mon Jul 9 14:45 2012"},
-
-		// Wrap around hours
-		{"Mon Jul 9 15:45 2012", "20-35/15 * * * *", "Mon Jul 9 15:35 2012"},
-
-		// Wrap around days
-		{"Mon Jul 10 00:00 2012", "*/15 * * * *", "Tue Jul 9 23:45 2012"},
-		{"Mon Jul 10 00:09:55 2012", "*/15 * * * *", "Tue Jul 10 00:00 2012"},
-
-		// 2am nightly job
-		{"2012-11-04T00:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-11-03T02:00:00-0400"},
-		{"2012-11-05T02:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-11-04T02:00:00-0500"},
-
-		// Wrap around months
-		{"Mon Aug 8 23:35 2012", "0 0 0 9 Apr-Oct ?", "Thu Jul 9 00:00 2012"},
-		{"Mon Jul 9 23:35 2012", "0 0 0 */5 Apr,Aug,Oct Mon", "Mon Apr 16 00:00 2012"},
-		{"Mon Dec 9 23:35 2012", "0 0 0 */5 Oct Mon", "Mon Oct 1 00:00 2012"},
+// prevRuns are the fixtures of TestPrev: the expected activation before each time.
+var prevRuns = []struct {
+	time, spec string
+	expected   string
+}{
+	// Simple cases
+	{"Mon Jul 9 14:45 2012", "0/15 * * * *", "Mon Jul 9 14:30 2012"},
+	{"Mon Jul 9 14:59 2012", "0/15 * * * *", "Mon Jul 9 14:45 2012"},
+	{"Mon Jul 9 14:59:59 2012", "0/15 * * * *", "Mon Jul 9 14:45 2012"},
+
+	// Wrap around hours
+	{"Mon Jul 9 15:45 2012", "20-35/15 * * * *", "Mon Jul 9 15:35 2012"},
+
+	// Wrap around days
+	{"Mon Jul 10 00:00 2012", "*/15 * * * *", "Tue Jul 9 23:45 2012"},
+	{"Mon Jul 10 00:09:55 2012", "*/15 * * * *", "Tue Jul 10 00:00 2012"},
+
+	// 2am nightly job
+	{"2012-11-04T00:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-11-03T02:00:00-0400"},
+	{"2012-11-05T02:00:00-0500", "TZ=America/New_York 0 0 2 * * ?", "2012-11-04T02:00:00-0500"},
+
+	// Wrap around months
+	{"Mon Aug 8 23:35 2012", "0 0 0 9 Apr-Oct ?", "Thu Jul 9 00:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 */5 Apr,Aug,Oct Mon", "Mon Apr 16 00:00 2012"},
+	{"Mon Dec 9 23:35 2012", "0 0 0 */5 Oct Mon", "Mon Oct 1 00:00 2012"},
+
+	// Wrap around years
+	{"Mon Feb 1 23:35 2013", "0 0 * Feb Mon", "Mon Feb 27 00:00 2012"},
+	{"Mon Jan 29 23:35 2013", "0 0 * Feb Mon/2", "Fri Feb 29 00:00 2012"},
+
+	// Builtin
+	{"Mon Feb 1 23:35 2013", "@hourly", "Mon Feb 1 23:00 2013"},
+	{"Mon Feb 1 23:35 2013", "@daily", "Mon Feb 1 00:00 2013"},
+	{"Mon Feb 1 23:35 2013", "@weekly", "Mon Jan 27 00:00 2013"},
+
+	// Exactly on an activation returns the one strictly before it
+	{"Mon Jul 9 14:45 2012", "0/15 * * * *", "Mon Jul 9 14:30 2012"},
+	{"Mon Jul 9 14:45:00.5 2012", "0/15 * * * *", "Mon Jul 9 14:45 2012"},
+
+	// The latest matching minute of an earlier hour
+	{"Mon Jul 9 14:37:12 2012", "0 0,30 13 * * *", "Mon Jul 9 13:30 2012"},
+	{"Mon Jul 9 14:37:12 2012", "15,45 0,30 13 * * *", "Mon Jul 9 13:30:45 2012"},
+
+	// Dom/Dow are ORed when both are restricted
+	{"Sun Jul 15 12:00 2012", "0 0 0 1,2 * Fri", "Fri Jul 13 00:00 2012"},
+
+	// Daylight savings time 2am EST (-5) -> 3am EDT (-4)
+	{"2012-03-11T03:00:00-0400", "TZ=America/New_York 0 30 2 * * ?", "2012-03-10T02:30:00-0500"},
+	{"2012-03-11T03:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T01:00:00-0500"},
+
+	// Daylight savings time 2am EDT (-4) => 1am EST (-5)
+	{"2012-11-04T01:30:00-0500", "TZ=America/New_York 0 0,30 1 * * ?", "2012-11-04T01:30:00-0400"},
+	{"2012-11-04T01:00:00-0500", "TZ=America/New_York 0 0,30 1 * * ?", "2012-11-04T01:30:00-0400"},
+	{"2012-11-04T02:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0500"},
+
+	// Last day of the month
+	{"Sat Mar 10 12:00 2012", "0 0 0 L * *", "Wed Feb 29 00:00 2012"},
+	{"Wed Feb 29 00:00 2012", "0 0 0 L * *", "Tue Jan 31 00:00 2012"},
+	{"Tue Jan 31 00:00 2012", "0 0 0 L * *", "Sat Dec 31 00:00 2011"},
+	{"Mon Jul 9 23:35 2012", "0 0 0 L Feb *", "Wed Feb 29 00:00 2012"},
+
+	// Nearest weekday
+	{"Mon Oct 1 00:00 2012", "0 0 0 1W * *", "Mon Sep 3 00:00 2012"},
+	{"Mon Oct 1 00:00 2012", "0 0 0 LW * *", "Fri Sep 28 00:00 2012"},
+
+	// Nth day of the week
+	{"Mon Jul 9 08:00 2012", "0 0 9 ? * MON#2", "Mon Jun 11 09:00 2012"},
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * FRI#5", "Fri Jun 29 09:00 2012"},
+
+	// Last day of the week in the month
+	{"Mon Jul 9 23:35 2012", "0 0 9 ? * FRIL", "Fri Jun 29 09:00 2012"},
+
+	// Year field
+	{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 2005", "Sat Jan 1 12:00 2005"},
+	{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 1990-2000/5", "Sat Jan 1 12:00 2000"},
+	{"Sat Jan 1 12:00 2000", "0 0 12 1 1 ? 1990-2000/5", "Sun Jan 1 12:00 1995"},
+	{"Tue Jan 1 00:00:00 2013", "* * * * * * 2010,2012", "Mon Dec 31 23:59:59 2012"},
+	{"Fri Feb 29 00:00 2104", "0 0 0 29 Feb ? 2092", "Fri Feb 29 00:00 2092"},
+
+	// Leap days across a century year that is not a leap year
+	{"Fri Feb 29 00:00 2104", "0 0 0 29 Feb ?", "Wed Feb 29 00:00 2096"},
+	{"Fri Feb 29 00:01 2104", "0 0 0 29 Feb ?", "Fri Feb 29 00:00 2104"},
+
+	// Unsatisfiable
+	{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
+	{"Mon Jul 9 23:35 2012", "0 0 0 * * * 2013", ""},
+	{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ? 1970-2012", ""},
+}
 
-		// Wrap around years
-		{"Mon Feb 1 23:35 2013", "0 0 * Feb Mon", "Mon Feb 27 00:00 2012"},
-		{"Mon Jan 29 23:35 2013", "0 0 * Feb Mon/2", "Fri Feb 29 00:00 2012"},
+func TestPrev(t *testing.T) {
+	for _, c := range prevRuns {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.(PrevSchedule).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+		if !actual.IsZero() && !actual.Before(getTime(c.time)) {
+			t.Errorf("%s, \"%s\": %v is not before the given time", c.time, c.spec, actual)
+		}
+	}
+}
 
-		// Builtin
-		{"Mon Feb 1 23:35 2013", "@hourly", "Mon Feb 1 23:00 2013"},
-		{"Mon Feb 1 23:35 2013", "@daily", "Mon Feb 1 00:00 2013"},
-		{"Mon Feb 1 23:35 2013", "@weekly", "Mon Jan 27 00:00 2013"},
+// TestNextPrevAgree walks each fixture of TestNext and TestPrev forward with
+// Next and back with Prev, checking that both visit the same activations.
+func TestNextPrevAgree(t *testing.T) {
+	const steps = 5
+	var runs []struct{ time, spec string }
+	for _, c := range nextRuns {
+		runs = append(runs, struct{ time, spec string }{c.time, c.spec})
+	}
+	for _, c := range prevRuns {
+		runs = append(runs, struct{ time, spec string }{c.time, c.spec})
 	}
 
 	for _, c := range runs {
@@ -208,10 +491,41 @@ func TestPrevious(t *testing.T) {
 			t.Error(err)
 			continue
 		}
-		actual := sched.Previous(getTime(c.time))
-		expected := getTime(c.expected)
-		if !actual.Equal(expected) {
-			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		s := sched.(PrevSchedule)
+
+		// A fixture's time that is itself an activation starts both walks, so
+		// that the steps to and from it are checked too.
+		var start []time.Time
+		if from := getTime(c.time); s.Next(from.Add(-time.Nanosecond)).Equal(from) {
+			start = append(start, from)
+		}
+
+		// Forward from the fixture's time, then back from the last activation.
+		forward := append([]time.Time(nil), start...)
+		for at := getTime(c.time); len(forward) < steps; {
+			if at = s.Next(at); at.IsZero() {
+				break
+			}
+			forward = append(forward, at)
+		}
+		for i := len(forward) - 1; i > 0; i-- {
+			if prev := s.Prev(forward[i]); !prev.Equal(forward[i-1]) {
+				t.Errorf("%s, \"%s\": Prev(%v) = %v, expected %v", c.time, c.spec, forward[i], prev, forward[i-1])
+			}
+		}
+
+		// Back from the fixture's time, then forward from the first activation.
+		back := append([]time.Time(nil), start...)
+		for at := getTime(c.time); len(back) < steps; {
+			if at = s.Prev(at); at.IsZero() {
+				break
+			}
+			back = append(back, at)
+		}
+		for i := len(back) - 1; i > 0; i-- {
+			if next := s.Next(back[i]); !next.Equal(back[i-1]) {
+				t.Errorf("%s, \"%s\": Next(%v) = %v, expected %v", c.time, c.spec, back[i], next, back[i-1])
+			}
 		}
 	}
 }
@@ -219,9 +533,38 @@ func TestPrevious(t *testing.T) {
 func TestErrors(t *testing.T) {
 	invalidSpecs := []string{
 		"xyz",
+		"TZ=UTC",
+		"CRON_TZ=UTC",
+		"CRON_TZ=Nowhere/Special * * * * *",
+		"CRON_TZ * * * * *",
 		"60 0 * * *",
 		"0 60 * * *",
 		"0 0 * * XYZ",
+		"0 0 0 1 1 ? 1969",
+		"0 0 0 1 1 ? 2100",
+		"0 0 0 1 1 ? 2030-2025",
+		"0 0 0 1 1 ? 2027 *",
+		"0 0 0 1-L * ?",
+		"0 0 L * * *",
+		"0 0 0 1-5W * ?",
+		"0 0 0 */2W * ?",
+		"0 0 0 32W * ?",
+		"0 0 0 W * ?",
+		"0 0 15W * * ?",
+		"0 0 0 * * 1W",
+		"0 0 0 * LW ?",
+		"0 0 0 ? * MON#0",
+		"0 0 0 ? * MON#6",
+		"0 0 0 ? * 8#1",
+		"0 0 0 ? * MON-FRI#1",
+		"0 0 0 ? * MON#",
+		"0 0 0 1#1 * ?",
+		"0 0 0 ? * L",
+		"0 0 0 ? * 8L",
+		"0 0 0 * * 8",
+		"0 0 0 * * 0-8",
+		"0 0 0 * 7-13 *",
+		"0 0 0 ? * XYZL",
 	}
 	for _, spec := range invalidSpecs {
 		_, err := Parse(spec)
@@ -231,6 +574,27 @@ func TestErrors(t *testing.T) {
 	}
 }
 
+func BenchmarkNext(b *testing.B) {
+	specs := []string{
+		"* * * * * *",
+		"0 0 0 * * *",
+		"0 0 0 29 Feb ?",
+		"0 0 0 LW * *",
+		"0 0 0 ? * FRI#5",
+		"0 0 0 1 1 ? 2099",
+		"0 0 0 30 Feb ?",
+	}
+	from := time.Date(2012, 7, 9, 0, 0, 0, 0, time.UTC)
+	for _, spec := range specs {
+		sched := MustParse("TZ=UTC " + spec)
+		b.Run(spec, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sched.Next(from)
+			}
+		})
+	}
+}
+
 func getTime(value string) time.Time {
 	if value == "" {
 		return time.Time{}
@@ -239,6 +603,7 @@ func getTime(value string) time.Time {
 	var layouts = []string{
 		"Mon Jan 2 15:04 2006",
 		"Mon Jan 2 15:04:05 2006",
+		"Mon Jan 2 15:04:05.999999999 2006",
 	}
 	for _, layout := range layouts {
 		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
@@ -0,0 +1,49 @@
+package cron
+
+import "strings"
+
+// maxSuggestDistance is the largest edit distance at which a misspelled word
+// is considered close enough to a known one to suggest it.
+const maxSuggestDistance = 2
+
+// suggest returns the candidate closest to word, ignoring case, if it is within
+// maxSuggestDistance edits of it.  Ties go to the earlier candidate.
+func suggest(word string, candidates []string) (string, bool) {
+	word = strings.ToLower(word)
+	best, bestDist := "", maxSuggestDistance+1
+	for _, c := range candidates {
+		if d := editDistance(word, strings.ToLower(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the number of single-character insertions, deletions,
+// substitutions and transpositions of adjacent characters needed to turn a
+// into b.
+func editDistance(a, b string) int {
+	// Keep the last two rows of the table; row i holds the distances between
+	// a[:i] and each prefix of b.
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
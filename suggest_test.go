@@ -0,0 +1,47 @@
+package cron
+
+import "testing"
+
+func TestEditDistance(t *testing.T) {
+	entries := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"daily", "daily", 0},
+		{"dialy", "daily", 1},
+		{"tues", "tue", 1},
+		{"sept", "sep", 1},
+		{"kitten", "sitting", 3},
+		{"ab", "ba", 1},
+		{"abc", "ca", 3},
+	}
+	for _, c := range entries {
+		if actual := editDistance(c.a, c.b); actual != c.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", c.a, c.b, actual, c.expected)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	entries := []struct {
+		word     string
+		expected string
+		ok       bool
+	}{
+		{"@dialy", "@daily", true},
+		{"@DAILY", "@daily", true},
+		{"@hourli", "@hourly", true},
+		{"@weekdays", "@weekday", true},
+		{"@bogus", "", false},
+		{"@", "", false},
+	}
+	for _, c := range entries {
+		actual, ok := suggest(c.word, descriptors)
+		if actual != c.expected || ok != c.ok {
+			t.Errorf("suggest(%q) = %q, %v, expected %q, %v", c.word, actual, ok, c.expected, c.ok)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// systemdShorthands maps the shorthand calendar events of systemd.time(7) to
+// their normalized form.
+var systemdShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"weekly":       "mon *-*-* 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// ParseSystemd returns a schedule for a systemd calendar event, as used by the
+// OnCalendar setting of systemd timers.  It accepts the core of the syntax
+// described in systemd.time(7):
+//
+//	[weekdays] [[year-]month-day] [hour:minute[:second]] [timezone]
+//
+// Weekdays are a list of names or ranges such as "Mon,Wed" or "Mon..Fri".
+// Each of the other components is "*", a value, a range "a..b", a repetition
+// "a/step" or "a..b/step", or a comma-separated list of those.  An omitted
+// date matches every day and an omitted time stands for midnight, while an
+// omitted second stands for 0.  The shorthands minutely, hourly, daily,
+// monthly, weekly, yearly, annually, quarterly and semiannually are accepted
+// as well, e.g. "Mon..Fri *-*-* 09:00", "*-*-01 00:00:00" or "*:0/15".
+//
+// Calendar events are returned as a *SpecSchedule.  Unlike a cron spec, a
+// calendar event restricting both the weekday and the day of the month
+// activates only on days matching both, so its DomDow policy is DomAndDow.
+// Constructs that are not supported, such as "~" for days counted from the end
+// of the month or fractional seconds, return an error naming them.
+func ParseSystemd(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 {
+		if normalized, ok := systemdShorthands[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(normalized)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty systemd calendar event")
+	}
+
+	s := &SpecSchedule{
+		Second:   1 << seconds.min,
+		Minute:   1 << minutes.min,
+		Hour:     1 << hours.min,
+		Dom:      all(dom),
+		Month:    all(months),
+		Dow:      all(dow),
+		Location: time.Local,
+	}
+	var (
+		weekdays         uint64
+		hasDate, hasTime bool
+		err              error
+	)
+	for i, field := range fields {
+		switch {
+		case strings.ContainsAny(field, "~"):
+			return nil, fmt.Errorf("unsupported systemd calendar syntax %q in %q", field, expr)
+		case strings.Contains(field, ":") && !hasTime:
+			err = parseSystemdTime(field, s)
+			hasTime = true
+		case strings.Contains(field, "-") && !hasDate && !hasTime && !isSystemdWeekdays(field):
+			err = parseSystemdDate(field, s)
+			hasDate = true
+		case i == 0 && isSystemdWeekdays(field):
+			weekdays, err = parseSystemdWeekdays(field)
+		case i == len(fields)-1 && i > 0:
+			s.Location, err = time.LoadLocation(field)
+			if err != nil || field == "Local" {
+				err = fmt.Errorf("unsupported systemd calendar syntax %q in %q", field, expr)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported systemd calendar syntax %q in %q", field, expr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v in %q", err, expr)
+		}
+	}
+
+	if weekdays != 0 {
+		s.Dow = weekdays
+	}
+	// A systemd event matches days that satisfy both its date and its
+	// weekdays, which only differs from the default when both are restricted.
+	if s.Dom&starBit == 0 && s.Dow&starBit == 0 {
+		s.DomDow = DomAndDow
+	}
+	return s, nil
+}
+
+// isSystemdWeekdays returns true if the field starts with a letter, as only
+// weekdays do.
+func isSystemdWeekdays(field string) bool {
+	c := field[0] | 0x20
+	return c >= 'a' && c <= 'z'
+}
+
+// parseSystemdWeekdays parses a list of weekday names and ranges into bits.
+func parseSystemdWeekdays(field string) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		lo, hi, isRange := strings.Cut(expr, "..")
+		if !isRange {
+			lo, hi, isRange = strings.Cut(expr, "-")
+		}
+		start, ok := systemdWeekday(lo)
+		end := start
+		if ok && isRange {
+			end, ok = systemdWeekday(hi)
+		}
+		if !ok || start > end {
+			return 0, fmt.Errorf("unsupported systemd weekday %q", expr)
+		}
+		bits |= getBits(start, end, 1)
+	}
+	return bits, nil
+}
+
+// systemdWeekday returns the day of the week for its English name or the
+// first three letters of it.
+func systemdWeekday(name string) (uint, bool) {
+	name = strings.ToLower(name)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return uint(d), true
+		}
+	}
+	return 0, false
+}
+
+// parseSystemdDate parses a date pattern, month-day or year-month-day, into
+// the schedule.
+func parseSystemdDate(field string, s *SpecSchedule) error {
+	parts := strings.Split(field, "-")
+	switch len(parts) {
+	case 2:
+	case 3:
+		years, err := getSystemdYears(parts[0])
+		if err != nil {
+			return err
+		}
+		s.Year = years
+		parts = parts[1:]
+	default:
+		return fmt.Errorf("unsupported systemd date %q", field)
+	}
+	var err error
+	if s.Month, err = getSystemdField(parts[0], months); err != nil {
+		return err
+	}
+	s.Dom, err = getSystemdField(parts[1], dom)
+	return err
+}
+
+// parseSystemdTime parses a time pattern, hour:minute or hour:minute:second,
+// into the schedule.
+func parseSystemdTime(field string, s *SpecSchedule) error {
+	parts := strings.Split(field, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("unsupported systemd time %q", field)
+	}
+	var err error
+	if s.Hour, err = getSystemdField(parts[0], hours); err != nil {
+		return err
+	}
+	if s.Minute, err = getSystemdField(parts[1], minutes); err != nil {
+		return err
+	}
+	if len(parts) == 3 {
+		s.Second, err = getSystemdField(parts[2], seconds)
+	}
+	return err
+}
+
+// getSystemdField parses a component of a systemd calendar event into bits
+// within the given bounds.  A component is "*" or a list of values, ranges
+// "a..b" and repetitions "a/step" or "a..b/step".
+func getSystemdField(field string, r bounds) (uint64, error) {
+	if field == "*" {
+		return all(r), nil
+	}
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		start, end, step, err := parseSystemdRange(expr, r)
+		if err != nil {
+			return 0, err
+		}
+		bits |= getBits(start, end, step)
+	}
+	return bits, nil
+}
+
+// getSystemdYears parses the year component of a systemd calendar event.
+func getSystemdYears(field string) (yearSet, error) {
+	var set yearSet
+	if field == "*" {
+		return set, nil
+	}
+	for _, expr := range strings.Split(field, ",") {
+		start, end, step, err := parseSystemdRange(expr, years)
+		if err != nil {
+			return yearSet{}, err
+		}
+		for y := start; y <= end; y += step {
+			set.add(y)
+		}
+	}
+	return set, nil
+}
+
+// parseSystemdRange parses a single value, range or repetition of a systemd
+// calendar event component.
+func parseSystemdRange(expr string, r bounds) (start, end, step uint, err error) {
+	unsupported := fmt.Errorf("unsupported systemd %s %q", r.field, expr)
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+	lo, hi, isRange := strings.Cut(rangeExpr, "..")
+
+	step = 1
+	if hasStep {
+		if step, err = mustParseInt(stepExpr); err != nil || step == 0 {
+			return 0, 0, 0, unsupported
+		}
+	}
+	if lo == "*" && hasStep && !isRange {
+		start = r.min
+	} else if start, err = mustParseInt(lo); err != nil {
+		return 0, 0, 0, unsupported
+	}
+	switch {
+	case isRange:
+		if end, err = mustParseInt(hi); err != nil {
+			return 0, 0, 0, unsupported
+		}
+	case hasStep:
+		end = r.max
+	default:
+		end = start
+	}
+	if start < r.min || end > r.max || start > end {
+		return 0, 0, 0, fmt.Errorf("systemd %s %q is beyond %d-%d", r.field, expr, r.min, r.max)
+	}
+	return start, end, step, nil
+}
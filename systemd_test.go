@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSystemd(t *testing.T) {
+	tests := []struct {
+		expr, spec string
+	}{
+		{"minutely", "0 * * * * *"},
+		{"hourly", "0 0 * * * *"},
+		{"Daily", "0 0 0 * * *"},
+		{"weekly", "0 0 0 * * MON"},
+		{"monthly", "0 0 0 1 * *"},
+		{"yearly", "0 0 0 1 1 *"},
+		{"quarterly", "0 0 0 1 1,4,7,10 *"},
+		{"semiannually", "0 0 0 1 1,7 *"},
+		{"*-*-* 10:30:00", "0 30 10 * * *"},
+		{"10:30", "0 30 10 * * *"},
+		{"*:0/15", "0 */15 * * * *"},
+		{"*:*:0/10", "*/10 * * * * *"},
+		{"0..6/2:00", "0 0 0,2,4,6 * * *"},
+		{"*-*-01", "0 0 0 1 * *"},
+		{"*-*-01,15 12:00", "0 0 12 1,15 * *"},
+		{"12-25", "0 0 0 25 12 *"},
+		{"2030-01-01 00:00:00", "0 0 0 1 1 * 2030"},
+		{"2030..2032-06-01", "0 0 0 1 6 * 2030-2032"},
+		{"Mon..Fri 09:00", "0 0 9 * * MON-FRI"},
+		{"Mon-Fri *-*-* 09:00", "0 0 9 * * MON-FRI"},
+		{"Sat,Sun 10:00", "0 0 10 * * SAT,SUN"},
+		{"Wednesday 08:15:30", "30 15 8 * * WED"},
+		{"*-*-* 09:00 UTC", "TZ=UTC 0 0 9 * * *"},
+	}
+	for _, c := range tests {
+		actual, err := ParseSystemd(c.expr)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.expr, err)
+			continue
+		}
+		expected, err := Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Equal(actual, expected) {
+			t.Errorf("%s => %v, expected %s", c.expr, actual, c.spec)
+		}
+		// None of these restrict both the date and the weekdays, so the policy
+		// is left at its default.
+		if str := actual.(*SpecSchedule).String(); strings.Contains(str, "DOMDOW=") {
+			t.Errorf("%s => String %q, expected no DOMDOW prefix", c.expr, str)
+		}
+	}
+}
+
+func TestParseSystemdBothDays(t *testing.T) {
+	sched, err := ParseSystemd("Mon *-*-1..7 09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy := sched.(*SpecSchedule).DomDow; policy != DomAndDow {
+		t.Fatalf("DomDow policy %v, expected DomAndDow", policy)
+	}
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	expected := []time.Time{
+		time.Date(2024, 2, 5, 9, 0, 0, 0, time.Local),
+		time.Date(2024, 3, 4, 9, 0, 0, 0, time.Local),
+		time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local),
+	}
+	for i, actual := range NextN(sched, from, len(expected)) {
+		if !actual.Equal(expected[i]) {
+			t.Errorf("activation %d: %v, expected %v", i, actual, expected[i])
+		}
+	}
+	if prev := sched.(PrevSchedule).Prev(from); !prev.Equal(time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("prev: %v, expected 2024-01-01 09:00", prev)
+	}
+
+	// The policy survives a round trip through the canonical spec.
+	str := sched.(*SpecSchedule).String()
+	if str != "DOMDOW=AND 0 0 9 1-7 * 1" {
+		t.Errorf("String: %q, expected %q", str, "DOMDOW=AND 0 0 9 1-7 * 1")
+	}
+	reparsed, err := Parse(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(reparsed.(*SpecSchedule), sched.(*SpecSchedule)) {
+		t.Errorf("%s reparses to a different schedule: %v", str, reparsed)
+	}
+	for i, actual := range NextN(reparsed, from, len(expected)) {
+		if !actual.Equal(expected[i]) {
+			t.Errorf("reparsed activation %d: %v, expected %v", i, actual, expected[i])
+		}
+	}
+}
+
+func TestParseSystemdErrors(t *testing.T) {
+	tests := []struct {
+		expr, err string
+	}{
+		{"", "empty systemd calendar event"},
+		{"*-02~03", `unsupported systemd calendar syntax "*-02~03"`},
+		{"*-*-* 10:30:00.5", `unsupported systemd seconds "00.5"`},
+		{"Funday 10:00", `unsupported systemd weekday "Funday"`},
+		{"Fri..Mon", `unsupported systemd weekday "Fri..Mon"`},
+		{"*-13-01", `systemd month "13" is beyond 1-12`},
+		{"25:00", `systemd hours "25" is beyond 0-23`},
+		{"*:0/0", `unsupported systemd minutes "0/0"`},
+		{"1-2-3-4", `unsupported systemd date "1-2-3-4"`},
+		{"10:00 Mars/Olympus_Mons", `unsupported systemd calendar syntax "Mars/Olympus_Mons"`},
+		{"10:00 11:00", `unsupported systemd calendar syntax "11:00"`},
+	}
+	for _, c := range tests {
+		_, err := ParseSystemd(c.expr)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%q => %v, expected error containing %q", c.expr, err, c.err)
+		}
+	}
+}
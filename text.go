@@ -0,0 +1,37 @@
+package cron
+
+// Spec holds a spec along with the schedule it was parsed into.  It implements
+// encoding.TextMarshaler and encoding.TextUnmarshaler, so it can be used
+// directly in configuration structs and with flag.TextVar, and an invalid spec
+// is reported as soon as the configuration is loaded.
+type Spec struct {
+	spec     string
+	schedule Schedule
+}
+
+// Schedule returns the parsed schedule, or nil if no spec has been
+// unmarshalled.
+func (s Spec) Schedule() Schedule {
+	return s.schedule
+}
+
+// String returns the spec as it was given.
+func (s Spec) String() string {
+	return s.spec
+}
+
+// MarshalText returns the spec as it was given.
+func (s Spec) MarshalText() ([]byte, error) {
+	return []byte(s.spec), nil
+}
+
+// UnmarshalText parses the spec using Parse, returning its error if the spec
+// is not valid.
+func (s *Spec) UnmarshalText(text []byte) error {
+	schedule, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	s.spec, s.schedule = string(text), schedule
+	return nil
+}
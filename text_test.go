@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSpecText(t *testing.T) {
+	var s Spec
+	if err := s.UnmarshalText([]byte("TZ=UTC @midnight")); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Schedule(), midnight(time.UTC)) {
+		t.Errorf("unexpected schedule: %v", s.Schedule())
+	}
+	if text, _ := s.MarshalText(); string(text) != "TZ=UTC @midnight" || s.String() != "TZ=UTC @midnight" {
+		t.Errorf("unexpected text: %s", text)
+	}
+
+	var perr *ParseError
+	if err := s.UnmarshalText([]byte("0 0 99 * *")); !errors.As(err, &perr) {
+		t.Errorf("expected a *ParseError, got %v", err)
+	}
+	if s.String() != "TZ=UTC @midnight" {
+		t.Errorf("expected a failed unmarshal to leave the spec unchanged, got %s", s)
+	}
+}
+
+func TestSpecConfig(t *testing.T) {
+	// JSON uses the text interfaces for string values.
+	var config struct {
+		Schedule Spec `json:"schedule"`
+	}
+	if err := json.Unmarshal([]byte(`{"schedule": "@every 5m"}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Schedule.Schedule() != Every(5*time.Minute) {
+		t.Errorf("unexpected schedule: %v", config.Schedule.Schedule())
+	}
+	data, err := json.Marshal(config)
+	if err != nil || string(data) != `{"schedule":"@every 5m"}` {
+		t.Errorf("unexpected encoding: %s, %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"schedule": "@bogus"}`), &config); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+
+	var spec Spec
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.TextVar(&spec, "schedule", Spec{}, "when to run")
+	if err := fs.Parse([]string{"-schedule", "30 0 * * *"}); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Schedule() == nil || spec.String() != "30 0 * * *" {
+		t.Errorf("unexpected flag value: %v", spec)
+	}
+}
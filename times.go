@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"iter"
+	"time"
+)
+
+// NextN returns the next n activation times of the schedule after the given
+// time, in order.  The result is shorter than n if the schedule stops
+// activating, which is when it returns the zero time or a time that is not
+// after the one it was given.
+func NextN(s Schedule, from time.Time, n int) []time.Time {
+	var times []time.Time
+	for t := from; len(times) < n; {
+		next := s.Next(t)
+		if next.IsZero() || !next.After(t) {
+			break
+		}
+		times = append(times, next)
+		t = next
+	}
+	return times
+}
+
+// Between returns the activation times of the schedule that are after start
+// and not after end, in order.  Like NextN, it stops early if the schedule
+// stops activating.
+func Between(s Schedule, start, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := start; ; {
+			next := s.Next(t)
+			if next.IsZero() || !next.After(t) || next.After(end) {
+				return
+			}
+			if !yield(next) {
+				return
+			}
+			t = next
+		}
+	}
+}
+
+// Count returns the number of activation times of the schedule that are after
+// start and not after end.
+func Count(s Schedule, start, end time.Time) int {
+	n := 0
+	for range Between(s, start, end) {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,147 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stuckSchedule returns the time it is given, as a broken Schedule might.
+type stuckSchedule struct{}
+
+func (stuckSchedule) Next(t time.Time) time.Time     { return t }
+func (stuckSchedule) Previous(t time.Time) time.Time { return t }
+
+// stallingSchedule activates every minute until its limit, after which it
+// returns times in the past, as a broken Schedule might.
+type stallingSchedule struct{ limit time.Time }
+
+func (s stallingSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.limit) {
+		return t.Add(time.Minute)
+	}
+	return t.Add(-time.Hour)
+}
+
+func (s stallingSchedule) Previous(t time.Time) time.Time { return t.Add(-time.Minute) }
+
+func TestNextN(t *testing.T) {
+	from := getTime("Mon Jul 9 14:45 2012")
+	tests := []struct {
+		spec     string
+		n        int
+		expected []string
+	}{
+		{"0 0 * * * *", 3, []string{"Mon Jul 9 15:00 2012", "Mon Jul 9 16:00 2012", "Mon Jul 9 17:00 2012"}},
+		{"@every 5m", 2, []string{"Mon Jul 9 14:50 2012", "Mon Jul 9 14:55 2012"}},
+		{"0 0 0 1 1 * 2013", 3, []string{"Tue Jan 1 00:00 2013"}},
+		{"0 0 0 1 1 * 2011", 3, nil},
+		{"* * * * * *", 0, nil},
+	}
+
+	for _, c := range tests {
+		var expected []time.Time
+		for _, e := range c.expected {
+			expected = append(expected, getTime(e))
+		}
+		actual := NextN(MustParse(c.spec), from, c.n)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s, %d: (expected) %v != %v (actual)", c.spec, c.n, expected, actual)
+		}
+	}
+
+	if actual := NextN(stuckSchedule{}, from, 5); len(actual) != 0 {
+		t.Errorf("expected no times from a stuck schedule, got %v", actual)
+	}
+	stalling := stallingSchedule{from.Add(2 * time.Minute)}
+	if actual := NextN(stalling, from, 5); len(actual) != 2 {
+		t.Errorf("expected 2 times from a stalling schedule, got %v", actual)
+	}
+}
+
+// TestBrokenSchedules checks that the helpers stop, rather than loop forever,
+// on schedules that stop advancing.
+func TestBrokenSchedules(t *testing.T) {
+	from := getTime("Mon Jul 9 00:00 2012")
+	stalling := stallingSchedule{from.Add(2 * time.Minute)}
+	if n := Count(stalling, from, from.Add(time.Hour)); n != 2 {
+		t.Errorf("Count => %d, expected 2", n)
+	}
+	if n := Count(stuckSchedule{}, from, from.Add(time.Hour)); n != 0 {
+		t.Errorf("Count => %d, expected 0", n)
+	}
+	if d := MinInterval(stalling, from, time.Hour); d != time.Minute {
+		t.Errorf("MinInterval => %v, expected 1m", d)
+	}
+	if d := MinInterval(stuckSchedule{}, from, time.Hour); d != 0 {
+		t.Errorf("MinInterval => %v, expected 0", d)
+	}
+	if times := Conflicts(stalling, MustParse("* * * * * *"), time.Hour, from); len(times) != 2 {
+		t.Errorf("Conflicts => %v, expected 2 times", times)
+	}
+	if times := Conflicts(MustParse("* * * * * *"), stuckSchedule{}, time.Hour, from); len(times) != 0 {
+		t.Errorf("Conflicts => %v, expected none", times)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		spec       string
+		start, end string
+		expected   []string
+	}{
+		{"0 0 */6 * * *", "Mon Jul 9 00:00 2012", "Tue Jul 10 00:00 2012", []string{
+			"Mon Jul 9 06:00 2012", "Mon Jul 9 12:00 2012", "Mon Jul 9 18:00 2012", "Tue Jul 10 00:00 2012",
+		}},
+		{"0 0 0 1 1 *", "Mon Jul 9 00:00 2012", "Tue Jul 10 00:00 2012", nil},
+		{"@every 1h", "Mon Jul 9 00:00 2012", "Mon Jul 9 01:30 2012", []string{"Mon Jul 9 01:00 2012"}},
+		{"* * * * * *", "Mon Jul 9 00:00 2012", "Mon Jul 9 00:00 2012", nil},
+
+		// DST transitions
+		{"TZ=America/New_York 0 30 * * * *", "2012-03-11T00:00:00-0500", "2012-03-11T04:00:00-0400", []string{
+			"2012-03-11T00:30:00-0500", "2012-03-11T01:30:00-0500", "2012-03-11T03:00:00-0400", "2012-03-11T03:30:00-0400",
+		}},
+		{"TZ=America/New_York 0 30 * * * *", "2012-11-04T00:00:00-0400", "2012-11-04T02:00:00-0500", []string{
+			"2012-11-04T00:30:00-0400", "2012-11-04T01:30:00-0400", "2012-11-04T01:30:00-0500",
+		}},
+	}
+
+	for _, c := range tests {
+		var expected, actual []time.Time
+		for _, e := range c.expected {
+			expected = append(expected, getTime(e))
+		}
+		for next := range Between(MustParse(c.spec), getTime(c.start), getTime(c.end)) {
+			actual = append(actual, next)
+		}
+		if len(actual) != len(expected) {
+			t.Errorf("%s, %s - %s: (expected) %v != %v (actual)", c.spec, c.start, c.end, expected, actual)
+			continue
+		}
+		for i := range actual {
+			if !actual[i].Equal(expected[i]) {
+				t.Errorf("%s, %s - %s: (expected) %v != %v (actual)", c.spec, c.start, c.end, expected, actual)
+				break
+			}
+		}
+		if n := Count(MustParse(c.spec), getTime(c.start), getTime(c.end)); n != len(expected) {
+			t.Errorf("%s, %s - %s: (expected) %d != %d (actual count)", c.spec, c.start, c.end, len(expected), n)
+		}
+	}
+
+	for range Between(stuckSchedule{}, getTime("Mon Jul 9 00:00 2012"), getTime("Tue Jul 10 00:00 2012")) {
+		t.Fatal("expected no times from a stuck schedule")
+	}
+
+	// Stopping early.
+	var first []time.Time
+	for next := range Between(MustParse("@hourly"), getTime("Mon Jul 9 00:00 2012"), getTime("Tue Jul 10 00:00 2012")) {
+		first = append(first, next)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if len(first) != 2 {
+		t.Errorf("expected to stop after 2 times, got %v", first)
+	}
+}
@@ -0,0 +1,52 @@
+package cron
+
+import "time"
+
+// UnionSchedule activates whenever any of its schedules does.  Schedules that
+// activate at the same instant result in a single activation.
+type UnionSchedule []Schedule
+
+// Union returns a Schedule that activates whenever any of the given schedules
+// does.  Nested unions are flattened.
+func Union(schedules ...Schedule) Schedule {
+	var union UnionSchedule
+	for _, s := range schedules {
+		if nested, ok := s.(UnionSchedule); ok {
+			union = append(union, nested...)
+			continue
+		}
+		union = append(union, s)
+	}
+	return union
+}
+
+// Next returns the earliest next activation of any of the schedules, or the
+// zero time if none of them activate again.
+func (schedule UnionSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, s := range schedule {
+		n := s.Next(t)
+		if !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// Prev returns the latest previous activation of any of the schedules, or the
+// zero time if none of them activated before.
+func (schedule UnionSchedule) Prev(t time.Time) time.Time {
+	var prev time.Time
+	for _, s := range schedule {
+		p := previous(s, t)
+		if !p.IsZero() && p.After(prev) {
+			prev = p
+		}
+	}
+	return prev
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule UnionSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnionNext(t *testing.T) {
+	weekdays := MustParse("0 0 18 * * MON-FRI")
+	saturdays := MustParse("0 0 10 * * SAT")
+	union := Union(weekdays, saturdays)
+
+	tests := []struct {
+		schedule Schedule
+		time     string
+		expected string
+	}{
+		{union, "Fri Jul 13 12:00 2012", "Fri Jul 13 18:00 2012"},
+		{union, "Fri Jul 13 18:00 2012", "Sat Jul 14 10:00 2012"},
+		{union, "Sat Jul 14 10:00 2012", "Mon Jul 16 18:00 2012"},
+
+		// Mixed types, and identical instants activate once.
+		{Union(MustParse("0 0 * * * *"), Every(30*time.Minute)), "Mon Jul 9 14:50 2012", "Mon Jul 9 15:00 2012"},
+		{Union(MustParse("0 0 * * * *"), MustParse("0 0 15 * * *")), "Mon Jul 9 15:00 2012", "Mon Jul 9 16:00 2012"},
+
+		// Nested unions.
+		{Union(union, MustParse("0 0 12 * * SUN")), "Sat Jul 14 10:00 2012", "Sun Jul 15 12:00 2012"},
+
+		// Components that stop activating are skipped.
+		{Union(MustParse("0 0 0 1 1 * 2011"), saturdays), "Fri Jul 13 12:00 2012", "Sat Jul 14 10:00 2012"},
+		{Union(MustParse("0 0 0 1 1 * 2011")), "Fri Jul 13 12:00 2012", ""},
+		{Union(), "Fri Jul 13 12:00 2012", ""},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.Next(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestUnionPrev(t *testing.T) {
+	union := Union(MustParse("0 0 18 * * MON-FRI"), MustParse("0 0 10 * * SAT"), MustParse("0 0 0 1 1 * 2013"))
+	tests := []struct {
+		time     string
+		expected string
+	}{
+		{"Mon Jul 16 12:00 2012", "Sat Jul 14 10:00 2012"},
+		{"Sat Jul 14 10:00 2012", "Fri Jul 13 18:00 2012"},
+	}
+
+	for _, c := range tests {
+		actual := union.(PrevSchedule).Prev(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+
+	if n := len(Union(union, Union(Every(time.Hour))).(UnionSchedule)); n != 4 {
+		t.Errorf("expected nested unions to be flattened into 4 schedules, got %d", n)
+	}
+}
+
+// previousOnlySchedule implements Schedule without Prev, as schedules written
+// before PrevSchedule do.
+type previousOnlySchedule struct{ Schedule }
+
+func (s previousOnlySchedule) Previous(t time.Time) time.Time { return s.Schedule.Previous(t) }
+
+func TestUnionPreviousOnly(t *testing.T) {
+	union := Union(previousOnlySchedule{MustParse("0 0 18 * * MON-FRI")}, MustParse("0 0 10 * * SAT"))
+	if _, ok := union.(UnionSchedule)[0].(PrevSchedule); ok {
+		t.Fatal("expected the wrapped schedule not to implement PrevSchedule")
+	}
+	from := getTime("Sat Jul 14 10:00 2012")
+	if actual, expected := union.(PrevSchedule).Prev(from), getTime("Fri Jul 13 18:00 2012"); actual != expected {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
@@ -0,0 +1,53 @@
+package cron
+
+import "time"
+
+// WindowSchedule restricts a schedule to the activations that fall within a
+// window of time.  A zero Start or End leaves that side of the window open.
+type WindowSchedule struct {
+	Schedule Schedule
+	Start    time.Time // activations before Start are skipped
+	End      time.Time // activations after End are skipped
+}
+
+// StartingAt returns a Schedule that activates like s, but not before t.
+func StartingAt(s Schedule, t time.Time) Schedule {
+	return WindowSchedule{Schedule: s, Start: t}
+}
+
+// Until returns a Schedule that activates like s, but not after t.  Once t has
+// passed, it returns the zero time.
+func Until(s Schedule, t time.Time) Schedule {
+	return WindowSchedule{Schedule: s, End: t}
+}
+
+// Next returns the next activation of the wrapped schedule within the window,
+// or the zero time if there is none.
+func (schedule WindowSchedule) Next(t time.Time) time.Time {
+	if !schedule.Start.IsZero() && t.Before(schedule.Start) {
+		t = schedule.Start.Add(-time.Nanosecond)
+	}
+	next := schedule.Schedule.Next(t)
+	if next.IsZero() || !schedule.End.IsZero() && next.After(schedule.End) {
+		return time.Time{}
+	}
+	return next
+}
+
+// Prev returns the previous activation of the wrapped schedule within the
+// window, or the zero time if there is none.
+func (schedule WindowSchedule) Prev(t time.Time) time.Time {
+	if !schedule.End.IsZero() && t.After(schedule.End) {
+		t = schedule.End.Add(time.Nanosecond)
+	}
+	prev := previous(schedule.Schedule, t)
+	if prev.IsZero() || !schedule.Start.IsZero() && prev.Before(schedule.Start) {
+		return time.Time{}
+	}
+	return prev
+}
+
+// Previous is the same as Prev, for the Schedule interface.
+func (schedule WindowSchedule) Previous(t time.Time) time.Time {
+	return schedule.Prev(t)
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowNext(t *testing.T) {
+	hourly := MustParse("0 0 * * * *")
+	start, end := getTime("Thu Mar 1 00:00 2012"), getTime("Sat Jun 30 23:59:59 2012")
+	window := Until(StartingAt(hourly, start), end)
+
+	tests := []struct {
+		schedule Schedule
+		time     string
+		expected string
+	}{
+		{window, "Mon Jan 9 14:45 2012", "Thu Mar 1 00:00 2012"},
+		{window, "Thu Mar 1 00:00 2012", "Thu Mar 1 01:00 2012"},
+		{window, "Mon Apr 9 14:45 2012", "Mon Apr 9 15:00 2012"},
+		{window, "Sat Jun 30 22:30 2012", "Sat Jun 30 23:00 2012"},
+		{window, "Sat Jun 30 23:00 2012", ""},
+		{window, "Mon Jul 9 14:45 2012", ""},
+
+		// Composed the other way around.
+		{StartingAt(Until(hourly, end), start), "Mon Jan 9 14:45 2012", "Thu Mar 1 00:00 2012"},
+		{StartingAt(Until(hourly, end), start), "Sat Jun 30 23:00 2012", ""},
+
+		// Activations exactly at the start and end are included.
+		{StartingAt(hourly, getTime("Thu Mar 1 05:00 2012")), "Thu Mar 1 04:30 2012", "Thu Mar 1 05:00 2012"},
+		{Until(hourly, getTime("Thu Mar 1 05:00 2012")), "Thu Mar 1 04:30 2012", "Thu Mar 1 05:00 2012"},
+
+		// A start within a second begins at the following second.
+		{StartingAt(MustParse("* * * * * *"), getTime("Thu Mar 1 05:00:00.5 2012")), "Thu Mar 1 04:30 2012", "Thu Mar 1 05:00:01 2012"},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.Next(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestWindowPrev(t *testing.T) {
+	hourly := MustParse("0 0 * * * *")
+	start, end := getTime("Thu Mar 1 00:00 2012"), getTime("Sat Jun 30 23:59:59 2012")
+	window := StartingAt(Until(hourly, end), start)
+
+	tests := []struct {
+		time     string
+		expected string
+	}{
+		{"Mon Jul 9 14:45 2012", "Sat Jun 30 23:00 2012"},
+		{"Mon Apr 9 14:45 2012", "Mon Apr 9 14:00 2012"},
+		{"Thu Mar 1 00:30 2012", "Thu Mar 1 00:00 2012"},
+		{"Thu Mar 1 00:00 2012", ""},
+		{"Mon Jan 9 14:45 2012", ""},
+	}
+
+	for _, c := range tests {
+		actual := window.(PrevSchedule).Prev(getTime(c.time))
+		if expected := getTime(c.expected); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestWindowStops(t *testing.T) {
+	window := Until(Every(time.Hour), getTime("Mon Jul 9 17:00 2012"))
+	times := NextN(window, getTime("Mon Jul 9 14:00 2012"), 10)
+	if len(times) != 3 {
+		t.Errorf("expected 3 activations before the end, got %v", times)
+	}
+}